@@ -0,0 +1,145 @@
+package golumn
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// AggFunc names a built-in aggregation applied to the values of a group. Nulls are ignored.
+type AggFunc string
+
+const (
+	AggSum    AggFunc = "sum"    // sum of values; 0 when there are none
+	AggMean   AggFunc = "mean"   // arithmetic mean as a float
+	AggMedian AggFunc = "median" // middle value as a float, averaging the two middle values
+	AggCount  AggFunc = "count"  // number of non-null values
+	AggMin    AggFunc = "min"    // smallest value
+	AggMax    AggFunc = "max"    // largest value
+	AggFirst  AggFunc = "first"  // first non-null value
+	AggLast   AggFunc = "last"   // last non-null value
+)
+
+// resultType returns the type of the series produced by applying a to a series of type t.
+func (a AggFunc) resultType(t series.Type) series.Type {
+	switch a {
+	case AggSum:
+		if t == series.Float {
+			return series.Float
+		}
+		return series.Int
+	case AggMean, AggMedian:
+		return series.Float
+	case AggCount:
+		return series.Int
+	case AggMin, AggMax, AggFirst, AggLast:
+		return t
+	default:
+		panic(fmt.Errorf("unknown aggregation %q", a))
+	}
+}
+
+// apply aggregates the non-null values of s at the given positions. It returns nil when
+// there are no values to aggregate, except for AggSum and AggCount which return 0.
+func (a AggFunc) apply(s series.Series, positions []int) any {
+	switch a {
+	case AggSum, AggMean, AggMedian:
+		if !s.IsNumeric() {
+			panic(fmt.Errorf("%v is only supported for numeric types, got %v", a, s.Type()))
+		}
+	}
+
+	var vals []any
+	for _, i := range positions {
+		if s.IsValid(i) {
+			vals = append(vals, s.Val(i))
+		}
+	}
+
+	switch a {
+	case AggCount:
+		return len(vals)
+	case AggSum:
+		if s.Type() == series.Float {
+			sum := 0.0
+			for _, v := range vals {
+				sum += v.(float64)
+			}
+			return sum
+		}
+		sum := 0
+		for _, v := range vals {
+			n, _ := toInt(v)
+			sum += n
+		}
+		return sum
+	}
+
+	if len(vals) == 0 {
+		return nil
+	}
+
+	switch a {
+	case AggMean:
+		sum := 0.0
+		for _, v := range vals {
+			f, _ := toFloat(v)
+			sum += f
+		}
+		return sum / float64(len(vals))
+	case AggMedian:
+		fs := make([]float64, len(vals))
+		for k, v := range vals {
+			fs[k], _ = toFloat(v)
+		}
+		slices.Sort(fs)
+		mid := len(fs) / 2
+		if len(fs)%2 == 1 {
+			return fs[mid]
+		}
+		return (fs[mid-1] + fs[mid]) / 2
+	case AggMin:
+		return slices.MinFunc(vals, compareValues)
+	case AggMax:
+		return slices.MaxFunc(vals, compareValues)
+	case AggFirst:
+		return vals[0]
+	case AggLast:
+		return vals[len(vals)-1]
+	default:
+		panic(fmt.Errorf("unknown aggregation %q", a))
+	}
+}
+
+// toFloat converts a numeric cell value (int, float64 or bool) to a float64.
+func toFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case float64:
+		return x, true
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// toInt converts an int or bool cell value to an int.
+func toInt(v any) (int, bool) {
+	switch x := v.(type) {
+	case int:
+		return x, true
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
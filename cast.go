@@ -0,0 +1,39 @@
+package golumn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// Cast returns a copy of the DataFrame with the named columns converted to the given types
+// (see series.Series.Cast). In strict mode the errors of every failing column are joined and
+// returned together with an empty DataFrame.
+func (df DataFrame) Cast(types map[string]series.Type, opts ...series.CastOptions) (DataFrame, error) {
+	for name := range types {
+		if df.columnIndex(name) == -1 {
+			panic(fmt.Errorf("column %v not found", name))
+		}
+	}
+
+	out := df.Copy()
+	var errs []error
+	for j, c := range out.columns {
+		t, ok := types[c.Name]
+		if !ok {
+			continue
+		}
+		cast, err := c.Cast(t, opts...)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out.columns[j] = cast
+	}
+
+	if len(errs) > 0 {
+		return DataFrame{}, errors.Join(errs...)
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package golumn
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestDataFrame_Cast(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b"}, series.String, "name"),
+		series.New([]string{"1", "x"}, series.String, "count"),
+		series.New([]string{"2024-01-02", "2024-01-03"}, series.String, "day"),
+	)
+
+	cast, err := df.Cast(map[string]series.Type{"count": series.Int, "day": series.Datetime})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, cast.Column("name").Type(), series.String)
+	assert.Equal(t, cast.Column("count").Val(0), any(1))
+	assert.Equal(t, cast.Column("count").IsNull(1), true)
+	assert.Equal(t, cast.Column("day").Val(1), any(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)))
+
+	// the source DataFrame is left untouched
+	assert.Equal(t, df.Column("count").Type(), series.String)
+
+	_, err = df.Cast(map[string]series.Type{"name": series.Float, "count": series.Int}, series.CastOptions{Strict: true})
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, strings.Count(err.Error(), "cannot cast"), 2)
+}
+
+func TestDatetimeKeys(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	local := day(2).In(time.FixedZone("X", 3600))
+
+	assert.Equal(t, compareValues(day(1), day(2)), -1)
+	assert.Equal(t, valuesEqual(day(2), local), true)
+
+	k := series.New([]time.Time{day(2), day(1), local}, series.Datetime, "k")
+	idx := newKeyIndex([]series.Series{k}, false)
+	assert.Equal(t, len(idx.groups), 2)
+
+	quotes := New(
+		series.New([]time.Time{day(1), day(3)}, series.Datetime, "at"),
+		series.New([]float64{10, 30}, series.Float, "price"),
+	)
+	trades := New(series.New([]time.Time{day(2), day(5)}, series.Datetime, "at"))
+	joined := trades.JoinAsOf(quotes, AsOfOptions{On: "at", Tolerance: 24 * time.Hour})
+	assert.Equal(t, joined.Column("price").Val(0), any(10.0))
+	assert.Equal(t, joined.Column("price").IsNull(1), true)
+}
@@ -0,0 +1,135 @@
+package golumn
+
+import (
+	"fmt"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// ConcatJoin controls how Concat handles columns that are not shared by every DataFrame.
+type ConcatJoin int
+
+const (
+	// ConcatOuter keeps the union of all columns, filling missing columns with nulls.
+	ConcatOuter ConcatJoin = iota
+	// ConcatInner keeps only the columns present in every DataFrame.
+	ConcatInner
+)
+
+// ConcatOptions defines optional settings for Concat.
+type ConcatOptions struct {
+	Join        ConcatJoin
+	IgnoreIndex bool // if true, the result is given a fresh range index
+}
+
+// Concat stacks DataFrames vertically, aligning columns by name. Columns are ordered by first
+// appearance, and a column present in several frames with different types is promoted to a
+// common type (see series.CommonType). Empty DataFrames are skipped.
+func Concat(frames []DataFrame, opts ...ConcatOptions) DataFrame {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var opt ConcatOptions
+	if len(opts) == 1 {
+		opt = opts[0]
+	}
+
+	parts := make([]DataFrame, 0, len(frames))
+	for _, f := range frames {
+		if f.ncols > 0 {
+			parts = append(parts, f)
+		}
+	}
+	if len(parts) == 0 {
+		return DataFrame{}
+	}
+
+	names := concatNames(parts, opt.Join)
+	if len(names) == 0 {
+		panic("no columns shared by all DataFrames")
+	}
+
+	total := 0
+	for _, f := range parts {
+		total += f.nrows
+	}
+
+	cols := make([]series.Series, len(names))
+	for j, name := range names {
+		var types []series.Type
+		for _, f := range parts {
+			if ci := f.columnIndex(name); ci != -1 {
+				types = append(types, f.columns[ci].Type())
+			}
+		}
+
+		vals := make([]any, 0, total)
+		for _, f := range parts {
+			ci := f.columnIndex(name)
+			for i := range f.nrows {
+				if ci == -1 {
+					vals = append(vals, nil)
+				} else {
+					vals = append(vals, f.columns[ci].Val(i))
+				}
+			}
+		}
+		cols[j] = series.New(vals, series.CommonType(types...), name)
+	}
+
+	df := New(cols...)
+	if opt.IgnoreIndex {
+		return df
+	}
+
+	nlevels := parts[0].index.NLevels()
+	levels := make([]series.Series, nlevels)
+	for l := range levels {
+		types := make([]series.Type, len(parts))
+		vals := make([]any, 0, total)
+		for k, f := range parts {
+			if f.index.NLevels() != nlevels {
+				panic(fmt.Errorf("cannot concatenate indexes with %v and %v levels", nlevels, f.index.NLevels()))
+			}
+			types[k] = f.index.levels[l].Type()
+			for i := range f.nrows {
+				vals = append(vals, f.index.levels[l].Val(i))
+			}
+		}
+		levels[l] = series.New(vals, series.CommonType(types...), parts[0].index.levels[l].Name)
+	}
+	df.index = newIndex(levels...)
+	return df
+}
+
+// AppendRows returns a new DataFrame with the rows of other stacked below those of df.
+// It is shorthand for Concat with default options.
+func (df DataFrame) AppendRows(other DataFrame) DataFrame {
+	return Concat([]DataFrame{df, other})
+}
+
+// concatNames returns the output column names for Concat in first-seen order.
+func concatNames(frames []DataFrame, join ConcatJoin) []string {
+	var names []string
+	seen := make(map[string]int)
+	for _, f := range frames {
+		for _, name := range f.Names() {
+			if _, ok := seen[name]; !ok {
+				names = append(names, name)
+			}
+			seen[name]++
+		}
+	}
+
+	if join != ConcatInner {
+		return names
+	}
+
+	shared := names[:0]
+	for _, name := range names {
+		if seen[name] == len(frames) {
+			shared = append(shared, name)
+		}
+	}
+	return shared
+}
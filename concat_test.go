@@ -0,0 +1,73 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestConcat_Outer(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2}, series.Int, "id"),
+		series.New([]int{10, 20}, series.Int, "val"),
+	)
+	b := New(
+		series.New([]int{3}, series.Int, "id"),
+		series.New([]float64{3.5}, series.Float, "val"),
+		series.New([]string{"x"}, series.String, "tag"),
+	)
+
+	df := Concat([]DataFrame{a, b})
+
+	r, c := df.Shape()
+	assert.Equal(t, r, 3)
+	assert.Equal(t, c, 3)
+	assert.Equal(t, df.Column("val").Type(), series.Float)
+	assert.Equal(t, df.Column("val").Val(0), 10.0)
+	assert.Equal(t, df.Column("val").Val(2), 3.5)
+	assert.Equal(t, df.Column("tag").IsNull(0), true)
+	assert.Equal(t, df.Column("tag").IsNull(1), true)
+	assert.Equal(t, df.Column("tag").Val(2), "x")
+	assert.Equal(t, df.Index().String(), "{Index [0 1 0] int}")
+}
+
+func TestConcat_InnerIgnoreIndex(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2}, series.Int, "id"),
+		series.New([]string{"a", "b"}, series.String, "name"),
+	)
+	b := New(
+		series.New([]string{"c"}, series.String, "name"),
+		series.New([]bool{true}, series.Boolean, "id"),
+	)
+
+	df := Concat([]DataFrame{a, b}, ConcatOptions{Join: ConcatInner, IgnoreIndex: true})
+
+	assert.Equal(t, len(df.Names()), 2)
+	assert.Equal(t, df.Names()[0], "id")
+	assert.Equal(t, df.Column("id").Type(), series.Int)
+	assert.Equal(t, df.Column("id").Val(2), 1)
+	assert.Equal(t, df.Column("name").Val(2), "c")
+	assert.Equal(t, df.Index().String(), "{Index [0 1 2] int}")
+}
+
+func TestConcat_SkipsEmptyAndAppendRows(t *testing.T) {
+	a := New(series.New([]int{1}, series.Int, "id"))
+	b := New(series.New([]int{2}, series.Int, "id"))
+
+	df := Concat([]DataFrame{{}, a, {}})
+	r, _ := df.Shape()
+	assert.Equal(t, r, 1)
+
+	df = a.AppendRows(b)
+	r, _ = df.Shape()
+	assert.Equal(t, r, 2)
+	assert.Equal(t, df.At(1, 0), 2)
+
+	empty := Concat(nil)
+	r, c := empty.Shape()
+	assert.Equal(t, r, 0)
+	assert.Equal(t, c, 0)
+}
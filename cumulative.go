@@ -0,0 +1,57 @@
+package golumn
+
+import "github.com/chriso345/golumn/series"
+
+// CumSum returns a copy of the DataFrame with the running sum of each numeric column.
+// Other columns and the index are kept as they are.
+func (df DataFrame) CumSum(opts ...series.CumOptions) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.CumSum(opts...) })
+}
+
+// CumProd returns a copy of the DataFrame with the running product of each numeric column.
+func (df DataFrame) CumProd(opts ...series.CumOptions) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.CumProd(opts...) })
+}
+
+// CumMax returns a copy of the DataFrame with the running maximum of each numeric column.
+func (df DataFrame) CumMax(opts ...series.CumOptions) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.CumMax(opts...) })
+}
+
+// CumMin returns a copy of the DataFrame with the running minimum of each numeric column.
+func (df DataFrame) CumMin(opts ...series.CumOptions) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.CumMin(opts...) })
+}
+
+// CumCount returns a copy of the DataFrame with the running count of non-null values of each
+// numeric column.
+func (df DataFrame) CumCount() DataFrame {
+	return df.mapNumeric(series.Series.CumCount)
+}
+
+// Shift returns a copy of the DataFrame with each numeric column shifted by periods positions,
+// filling vacated positions with fill (see series.Series.Shift).
+func (df DataFrame) Shift(periods int, fill any) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.Shift(periods, fill) })
+}
+
+// Diff returns a copy of the DataFrame with the periods-position difference of each numeric column.
+func (df DataFrame) Diff(periods int) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.Diff(periods) })
+}
+
+// PctChange returns a copy of the DataFrame with the periods-position fractional change of each
+// numeric column.
+func (df DataFrame) PctChange(periods int) DataFrame {
+	return df.mapNumeric(func(s series.Series) series.Series { return s.PctChange(periods) })
+}
+
+// mapNumeric returns a copy of the DataFrame with fn applied to every numeric column.
+func (df DataFrame) mapNumeric(fn func(series.Series) series.Series) DataFrame {
+	out := df.Copy()
+	for _, name := range df.SelectNumericNames() {
+		j := out.columnIndex(name)
+		out.columns[j] = fn(out.columns[j])
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestDataFrame_CumulativeAndShift(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "c"}, series.String, "Name"),
+		series.New([]int{1, 2, 3}, series.Int, "Count"),
+		series.New([]float64{1.5, 0.5, 2}, series.Float, "Score"),
+	)
+
+	cum := df.CumSum()
+	assert.Equal(t, cum.String(), "   Name  Count  Score\n0     a      1    1.5\n1     b      3      2\n2     c      6      4")
+
+	diff := df.Diff(1)
+	assert.Equal(t, diff.Column("Name").Val(0), any("a"))
+	assert.Equal(t, diff.Column("Count").IsNull(0), true)
+	assert.Equal(t, diff.Column("Count").Val(2), any(1))
+	assert.Equal(t, diff.Column("Score").Val(2), any(1.5))
+
+	shifted := df.Shift(1, 0)
+	assert.Equal(t, shifted.Column("Count").Val(0), any(0))
+	assert.Equal(t, shifted.Column("Score").Val(2), any(0.5))
+
+	// the source DataFrame is left untouched
+	assert.Equal(t, df.Column("Count").Val(2), any(3))
+}
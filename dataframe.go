@@ -12,10 +12,13 @@ import (
 // It is similar to a table in a relational database, and is implemented
 // similar to a dataframe in R or Python (pandas).
 type DataFrame struct {
-	index   series.Series
+	index   MultiIndex
 	columns []series.Series
 	ncols   int
 	nrows   int
+	// lookup caches a hash index over the index labels; see Loc. New, SetIndex and ResetIndex
+	// set it, so only a zero DataFrame is without one, and it has no labels to cache.
+	lookup *indexLookup
 }
 
 // New creates a new DataFrame from a collection of series.Series.
@@ -25,14 +28,6 @@ func New(se ...series.Series) DataFrame {
 		panic("empty Series")
 	}
 
-	// Create index
-	indices := make([]int, se[0].Len())
-	for i := range se[0].Len() {
-		indices[i] = i
-	}
-
-	index := series.New(indices, series.Int, "Index")
-
 	columns := make([]series.Series, len(se))
 	for i, s := range se {
 		columns[i] = s.Copy()
@@ -43,10 +38,11 @@ func New(se ...series.Series) DataFrame {
 	}
 
 	df := DataFrame{
-		index:   index,
+		index:   rangeIndex(nrows),
 		columns: columns,
 		ncols:   ncols,
 		nrows:   nrows,
+		lookup:  &indexLookup{},
 	}
 
 	// TODO: Currently assuming that column names are unique
@@ -77,26 +73,17 @@ func checkColumnDimensions(se ...series.Series) (ncols int, nrows int, err error
 func (df DataFrame) String() string {
 	var sb strings.Builder
 
+	labels := df.index.printedLabels(positionRange(0, df.nrows))
 	maxIndexWidth := 0
-	for i := range df.nrows {
-		width := len(fmt.Sprint(df.index.Val(i)))
-		if width > maxIndexWidth {
-			maxIndexWidth = width
-		}
+	if len(labels) > 0 {
+		maxIndexWidth = len(labels[0])
 	}
 
 	colWidths := make([]int, df.ncols)
 	for j, col := range df.columns {
 		maxWidth := len(col.Name)
 		for i := 0; i < df.nrows; i++ {
-			v := col.Val(i)
-			var sval string
-			if v == nil {
-				sval = ""
-			} else {
-				sval = fmt.Sprint(v)
-			}
-			valWidth := len(sval)
+			valWidth := len(col.FormatVal(i))
 			if valWidth > maxWidth {
 				maxWidth = valWidth
 			}
@@ -115,19 +102,11 @@ func (df DataFrame) String() string {
 	sb.WriteString("\n")
 
 	for i := 0; i < df.nrows; i++ {
-		indexStr := fmt.Sprint(df.index.Val(i))
-		sb.WriteString(padLeft(indexStr, maxIndexWidth))
+		sb.WriteString(labels[i])
 		sb.WriteString("  ")
 
 		for j, col := range df.columns {
-			v := col.Val(i)
-			var val string
-			if v == nil {
-				val = ""
-			} else {
-				val = fmt.Sprint(v)
-			}
-			sb.WriteString(padLeft(val, colWidths[j]))
+			sb.WriteString(padLeft(col.FormatVal(i), colWidths[j]))
 			if j < df.ncols-1 {
 				sb.WriteString("  ")
 			}
@@ -155,6 +134,11 @@ func (df DataFrame) Columns() []series.Series {
 	return df.columns
 }
 
+// SetFormat sets the NumberFormat used to print the named column, e.g. by String and dfio.ToCSV.
+func (df DataFrame) SetFormat(column string, f series.NumberFormat) {
+	df.Column(column).SetFormat(f)
+}
+
 // Column returns a series.Series of the DataFrame by name.
 func (df DataFrame) Column(name string) *series.Series {
 	for i := range df.columns {
@@ -165,6 +149,16 @@ func (df DataFrame) Column(name string) *series.Series {
 	panic(fmt.Errorf("column %v not found", name))
 }
 
+// columnIndex returns the position of the named column, or -1 if it does not exist.
+func (df DataFrame) columnIndex(name string) int {
+	for i := range df.columns {
+		if df.columns[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // Names returns a collection of the names of the series.Series of the DataFrame.
 func (df DataFrame) Names() []string {
 	names := make([]string, df.ncols)
@@ -174,30 +168,39 @@ func (df DataFrame) Names() []string {
 	return names
 }
 
-// SetIndex sets the index of the DataFrame to a specified series.Series.
-func (df DataFrame) SetIndex(s series.Series) DataFrame {
-	if df.nrows != s.Len() {
-		panic(fmt.Errorf("index length %v does not match DataFrame length %v", s.Len(), df.nrows))
+// SetIndex sets the index of the DataFrame to the given series. Passing several series sets a
+// MultiIndex with one level per series, outermost first.
+func (df DataFrame) SetIndex(levels ...series.Series) DataFrame {
+	if len(levels) == 0 {
+		panic("no index levels specified")
+	}
+	for _, s := range levels {
+		if df.nrows != s.Len() {
+			panic(fmt.Errorf("index length %v does not match DataFrame length %v", s.Len(), df.nrows))
+		}
 	}
 
-	df.index = s.Copy()
+	df.index = NewMultiIndex(levels...)
+	df.lookup = &indexLookup{}
 	return df
 }
 
 // ResetIndex resets the index of the DataFrame to a range of integers.
 func (df DataFrame) ResetIndex() DataFrame {
-	indices := make([]int, df.nrows)
-	for i := range df.nrows {
-		indices[i] = i
-	}
-
-	df.index = series.New(indices, series.Int, "Index")
+	df.index = rangeIndex(df.nrows)
+	df.lookup = &indexLookup{}
 	return df
 }
 
-// Index returns the index of the DataFrame.
+// Index returns a copy of the index of the DataFrame. Changing it leaves the DataFrame
+// untouched; use SetIndex to replace the index. On a MultiIndex only the outermost level is
+// returned; use MultiIndex to read every level. A zero DataFrame has no index and gives an
+// empty Series.
 func (df DataFrame) Index() series.Series {
-	return df.index
+	if df.index.NLevels() == 0 {
+		return series.Series{}
+	}
+	return df.index.levels[0].Copy()
 }
 
 // Slice returns a new DataFrame with rows from a to b
@@ -216,7 +219,19 @@ func (df DataFrame) Slice(a, b int) DataFrame {
 	}
 
 	dfNew := New(s...)
-	dfNew.index = df.index.Slice(a, b)
+	dfNew.index = df.index.slice(a, b)
+	return dfNew
+}
+
+// takeRows returns a new DataFrame holding the rows at the given positions, index included.
+func (df DataFrame) takeRows(positions []int) DataFrame {
+	cols := make([]series.Series, df.ncols)
+	for j, c := range df.columns {
+		cols[j] = c.Take(positions)
+	}
+
+	dfNew := New(cols...)
+	dfNew.index = df.index.take(positions)
 	return dfNew
 }
 
@@ -258,46 +273,21 @@ func (df DataFrame) At(i, j int) any {
 
 // Swap swaps the rows at index row1 and row2 of the DataFrame inplace.
 func (df DataFrame) Swap(row1, row2 int) {
-	// Swap index
-	temp := df.index.Val(row1)
-	df.index.Elem(row1).Set(df.index.Val(row2))
-	df.index.Elem(row2).Set(temp)
-
-	// Swap rows
+	df.index.swap(row1, row2)
+	df.lookup.reset()
 	for k := range df.ncols {
-		temp := df.columns[k].Val(row1)
-		df.columns[k].Elem(row1).Set(df.columns[k].Val(row2))
-		df.columns[k].Elem(row2).Set(temp)
+		df.columns[k].Swap(row1, row2)
 	}
 }
 
-// Sort sorts the DataFrame inplace according to the specified columns.
+// Sort sorts the DataFrame inplace according to the specified columns, in ascending order
+// with nulls last. Rows that compare equal keep their relative order.
 func (df DataFrame) Sort(columns ...string) {
 	if len(columns) == 0 {
 		panic("no columns specified")
 	}
 
-	if len(columns) > 1 {
-		panic("> 1 column not yet implemented")
-	}
-
-	column := df.Column(columns[0])
-
-	// Sort via bubble sort according to specified column
-	for i := range df.nrows {
-		for j := range df.nrows - i - 1 {
-			switch column.Type() {
-			case series.Int:
-				if column.Val(j).(int) > column.Val(j+1).(int) {
-					df.Swap(j, j+1)
-				}
-			case series.Float:
-				if column.Val(j).(float64) > column.Val(j+1).(float64) {
-					df.Swap(j, j+1)
-				}
-			}
-		}
-	}
+	df.Order(sortedPositions(df.keyColumns(columns), df.nrows)...)
 }
 
 // Order orders the DataFrame inplace according to the specified positions.
@@ -339,15 +329,19 @@ func (df *DataFrame) Append(s series.Series) {
 	df.ncols++
 }
 
-// Copy returns a deep copy of the DataFrame.
+// Copy returns a deep copy of the DataFrame. A zero DataFrame, such as GroupBy.Aggregate returns
+// when no group gives a row, copies to a zero DataFrame.
 func (df DataFrame) Copy() DataFrame {
+	if df.ncols == 0 {
+		return DataFrame{}
+	}
 	var s []series.Series
 	for _, se := range df.columns {
 		s = append(s, se.Copy())
 	}
 
 	dfNew := New(s...)
-	dfNew.index = df.index.Copy()
+	dfNew.index = df.index.copy()
 	return dfNew
 }
 
@@ -407,368 +401,3 @@ func (df DataFrame) Apply(fn func(row *Row)) DataFrame {
 
 	return df
 }
-
-// DropNA removes rows that contain any nulls and returns a new DataFrame.
-func (df DataFrame) DropNA() DataFrame {
-	keep := make([]int, 0, df.nrows)
-	for i := 0; i < df.nrows; i++ {
-		hasNull := false
-		for _, col := range df.columns {
-			if col.IsNull(i) {
-				hasNull = true
-				break
-			}
-		}
-		if !hasNull {
-			keep = append(keep, i)
-		}
-	}
-	if len(keep) == 0 {
-		return DataFrame{}
-	}
-	outCols := make([]series.Series, len(df.columns))
-	for ci, c := range df.columns {
-		outCols[ci] = series.NewEmptySeries(c.Type(), 0, c.Name)
-	}
-	for _, i := range keep {
-		for ci := range df.columns {
-			outCols[ci].Append(df.columns[ci].Val(i))
-		}
-	}
-	return New(outCols...)
-}
-
-// internal join implementation supporting modes: "inner", "left", "right", "full".
-// onL/onR may be column names or "__index__" to join on the DataFrame index.
-func (df DataFrame) joinInternal(other DataFrame, onL, onR, mode string) DataFrame {
-	// getters for join keys
-	var getLeftVal func(int) any
-	var getRightVal func(int) any
-	lIsIndex := onL == "__index__"
-	rIsIndex := onR == "__index__"
-	if lIsIndex {
-		getLeftVal = func(i int) any { return df.index.Val(i) }
-	} else {
-		lcol := df.Column(onL)
-		getLeftVal = func(i int) any { return lcol.Val(i) }
-	}
-	if rIsIndex {
-		getRightVal = func(i int) any { return other.index.Val(i) }
-	} else {
-		rcol := other.Column(onR)
-		getRightVal = func(i int) any { return rcol.Val(i) }
-	}
-
-	leftIdx := make(map[any][]int)
-	rightIdx := make(map[any][]int)
-	for i := 0; i < df.nrows; i++ {
-		// skip rows with null join key
-		if !lIsIndex {
-			if df.Column(onL).IsNull(i) {
-				continue
-			}
-		}
-		leftIdx[getLeftVal(i)] = append(leftIdx[getLeftVal(i)], i)
-	}
-	for i := 0; i < other.nrows; i++ {
-		// skip rows with null join key
-		if !rIsIndex {
-			if other.Column(onR).IsNull(i) {
-				continue
-			}
-		}
-		rightIdx[getRightVal(i)] = append(rightIdx[getRightVal(i)], i)
-	}
-
-	// prepare output columns: left columns then right (skip right join column when it's a column)
-	outCols := make([]series.Series, 0, df.ncols+other.ncols)
-	for _, s := range df.columns {
-		outCols = append(outCols, series.NewEmptySeries(s.Type(), 0, s.Name))
-	}
-	// find right join index if needed
-	rJoinIdx := -1
-	if !rIsIndex {
-		for idx, s := range other.columns {
-			if s.Name == onR {
-				rJoinIdx = idx
-				break
-			}
-		}
-	}
-	// find left join index if needed
-	lJoinIdx := -1
-	if !lIsIndex {
-		for idx, s := range df.columns {
-			if s.Name == onL {
-				lJoinIdx = idx
-				break
-			}
-		}
-	}
-	for _, s := range other.columns {
-		if !rIsIndex && s.Name == onR {
-			continue
-		}
-		name := s.Name
-		for _, ls := range df.columns {
-			if ls.Name == name {
-				name = name + "_y"
-				break
-			}
-		}
-		outCols = append(outCols, series.NewEmptySeries(s.Type(), 0, name))
-	}
-
-	// helper to append a combined row; li or ri may be nil to indicate missing side
-	appendRow := func(li *int, ri *int) {
-		// left values
-		for j, s := range df.columns {
-			if li == nil {
-				// if left side missing but join key exists on right and left has the join column, populate it
-				if lJoinIdx == j && ri != nil && !rIsIndex {
-					outCols[j].Append(getRightVal(*ri))
-				} else {
-					// append zero placeholder for missing left-side value (preserve old behavior)
-					outCols[j].Append(zeroForType(s.Type()))
-				}
-			} else {
-				outCols[j].Append(s.Val(*li))
-			}
-		}
-		// right values
-		outOffset := df.ncols
-		for j, s := range other.columns {
-			if !rIsIndex && j == rJoinIdx {
-				continue
-			}
-			if ri == nil {
-				outCols[outOffset].Append(zeroForType(s.Type()))
-			} else {
-				outCols[outOffset].Append(s.Val(*ri))
-			}
-			outOffset++
-		}
-	}
-
-	seen := make(map[any]bool)
-	// process left-side keys
-	for k, lrows := range leftIdx {
-		rrows, ok := rightIdx[k]
-		if ok {
-			if mode == "right" {
-				// in right mode we will handle matches only when iterating right-only later
-				// but still emit matches for completeness
-			}
-			for _, li := range lrows {
-				for _, ri := range rrows {
-					tmpLi := li
-					tmpRi := ri
-					appendRow(&tmpLi, &tmpRi)
-				}
-			}
-		} else {
-			if mode == "left" || mode == "full" {
-				for _, li := range lrows {
-					tmpLi := li
-					appendRow(&tmpLi, nil)
-				}
-			}
-		}
-		seen[k] = true
-	}
-
-	// right-only keys for right or full
-	if mode == "right" || mode == "full" {
-		for k, rrows := range rightIdx {
-			if seen[k] {
-				continue
-			}
-			for _, ri := range rrows {
-				tmpRi := ri
-				appendRow(nil, &tmpRi)
-			}
-		}
-	}
-
-	return New(outCols...)
-}
-
-// Join performs an inner join with another DataFrame on the specified column name.
-// If column names collide (other than the join column), suffix "_y" is added to the right-hand columns.
-func (df DataFrame) Join(other DataFrame, on string) DataFrame {
-	return df.joinInternal(other, on, on, "inner")
-}
-
-// JoinLeft performs a left outer join on column 'on'.
-func (df DataFrame) JoinLeft(other DataFrame, on string) DataFrame {
-	return df.joinInternal(other, on, on, "left")
-}
-
-// JoinRight performs a right outer join on column 'on'.
-func (df DataFrame) JoinRight(other DataFrame, on string) DataFrame {
-	return df.joinInternal(other, on, on, "right")
-}
-
-// JoinFull performs a full outer join on column 'on'.
-func (df DataFrame) JoinFull(other DataFrame, on string) DataFrame {
-	return df.joinInternal(other, on, on, "full")
-}
-
-// JoinIndex performs an inner join on the DataFrame indices.
-func (df DataFrame) JoinIndex(other DataFrame) DataFrame {
-	return df.joinInternal(other, "__index__", "__index__", "inner")
-}
-
-// JoinLeftIndex performs a left outer join on the DataFrame indices.
-func (df DataFrame) JoinLeftIndex(other DataFrame) DataFrame {
-	return df.joinInternal(other, "__index__", "__index__", "left")
-}
-
-// JoinRightIndex performs a right outer join on the DataFrame indices.
-func (df DataFrame) JoinRightIndex(other DataFrame) DataFrame {
-	return df.joinInternal(other, "__index__", "__index__", "right")
-}
-
-// JoinFullIndex performs a full outer join on the DataFrame indices.
-func (df DataFrame) JoinFullIndex(other DataFrame) DataFrame {
-	return df.joinInternal(other, "__index__", "__index__", "full")
-}
-
-// Pivot creates a pivot table with indexCol as rows, columnsCol as columns and valuesCol as cell values.
-// Aggregation is done by taking the first encountered value for the index/column pair.
-func (df DataFrame) Pivot(indexCol, columnsCol, valuesCol string) DataFrame {
-	idxSeries := df.Column(indexCol)
-	colSeries := df.Column(columnsCol)
-	valSeries := df.Column(valuesCol)
-
-	// collect unique indices and columns in order
-	indexOrder := []any{}
-	indexSeen := make(map[any]bool)
-	colOrder := []any{}
-	colSeen := make(map[any]bool)
-
-	for i := 0; i < df.nrows; i++ {
-		iv := idxSeries.Val(i)
-		cv := colSeries.Val(i)
-		if !indexSeen[iv] {
-			indexOrder = append(indexOrder, iv)
-			indexSeen[iv] = true
-		}
-		if !colSeen[cv] {
-			colOrder = append(colOrder, cv)
-			colSeen[cv] = true
-		}
-	}
-
-	// map of index->col->value
-	cells := make(map[any]map[any]any)
-	for i := 0; i < df.nrows; i++ {
-		iv := idxSeries.Val(i)
-		cv := colSeries.Val(i)
-		if cells[iv] == nil {
-			cells[iv] = make(map[any]any)
-		}
-		if _, ok := cells[iv][cv]; !ok {
-			cells[iv][cv] = valSeries.Val(i)
-		}
-	}
-
-	// prepare output series: index column plus one column per unique column value
-	outCols := make([]series.Series, 0, 1+len(colOrder))
-	// index series: reuse type
-	outCols = append(outCols, series.NewEmptySeries(idxSeries.Type(), 0, indexCol))
-	for _, c := range colOrder {
-		name := fmt.Sprint(c)
-		outCols = append(outCols, series.NewEmptySeries(valSeries.Type(), 0, name))
-	}
-
-	// populate rows
-	for _, iv := range indexOrder {
-		outCols[0].Append(iv)
-		for j, cv := range colOrder {
-			vmap := cells[iv]
-			if vmap == nil {
-				outCols[j+1].Append(nil)
-				continue
-			}
-			if v, ok := vmap[cv]; ok {
-				outCols[j+1].Append(v)
-			} else {
-				outCols[j+1].Append(nil)
-			}
-		}
-	}
-
-	return New(outCols...)
-}
-
-// Unpivot melts the DataFrame from wide to long format. idVars are kept as identifier columns;
-// all other columns become variable/value pairs with names varName and valueName.
-func (df DataFrame) Unpivot(idVars []string, varName, valueName string) DataFrame {
-	idSet := make(map[string]bool)
-	for _, v := range idVars {
-		idSet[v] = true
-	}
-
-	// determine value columns
-	valCols := []series.Series{}
-	for _, s := range df.columns {
-		if idSet[s.Name] {
-			continue
-		}
-		valCols = append(valCols, s)
-	}
-
-	// prepare output: idVars..., varName (string), valueName (type depends on first valCol)
-	outCols := []series.Series{}
-	for _, id := range idVars {
-		outCols = append(outCols, series.NewEmptySeries(df.Column(id).Type(), 0, id))
-	}
-	outCols = append(outCols, series.NewEmptySeries(series.String, 0, varName))
-	// value type: use first valCols if exists else string
-	valType := series.String
-	if len(valCols) > 0 {
-		valType = valCols[0].Type()
-	}
-	outCols = append(outCols, series.NewEmptySeries(valType, 0, valueName))
-
-	for i := 0; i < df.nrows; i++ {
-		// id values
-		idVals := make([]any, len(idVars))
-		for j, id := range idVars {
-			idVals[j] = df.Column(id).Val(i)
-		}
-		for _, vc := range valCols {
-			v := vc.Val(i)
-			// skip placeholder zero values to avoid emitting missing combinations
-			if vc.IsNull(i) {
-				continue
-			}
-			// append id values
-			for j := range idVars {
-				outCols[j].Append(idVals[j])
-			}
-			// var name
-			outCols[len(idVars)].Append(vc.Name)
-			// value
-			outCols[len(idVars)+1].Append(v)
-		}
-	}
-
-	return New(outCols...)
-}
-
-func zeroForType(t series.Type) any {
-	switch t {
-	case series.Int:
-		return 0
-	case series.Float:
-		return 0.0
-	case series.Boolean:
-		return false
-	case series.String:
-		return ""
-	default:
-		return nil
-	}
-}
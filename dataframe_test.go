@@ -182,6 +182,23 @@ func TestDataFrame_Order(t *testing.T) {
 	assert.Equal(t, df.String(), expected)
 }
 
+func TestDataFrame_Swap(t *testing.T) {
+	df := New(
+		series.New([]any{1, nil, 3}, series.Int, "Integers"),
+		series.New([]any{nil, "b", "c"}, series.String, "Strings"),
+	)
+	df.Swap(0, 1)
+
+	assert.Equal(t, df.At(0, 0), nil)
+	assert.Equal(t, df.At(1, 0), 1)
+	assert.Equal(t, df.At(0, 1), "b")
+	assert.Equal(t, df.At(1, 1), nil)
+	assert.Equal(t, df.Column("Integers").IsNull(0), true)
+	assert.Equal(t, df.Column("Strings").IsNull(0), false)
+	assert.Equal(t, df.Column("Strings").CountNulls(), 1)
+	assert.Equal(t, df.Index().Val(0), 1)
+}
+
 func TestDataFrame_Append(t *testing.T) {
 	expected := "   Integers  Floats\n0         1     4.4\n1         2     5.5\n2         3     6.6"
 
@@ -325,7 +342,7 @@ func TestJoinLeftRightFull(t *testing.T) {
 		t.Fatalf("expected column 'name' in join result, got %v", jl.Names())
 	}
 	assert.Equal(t, jl.At(row1, nameIdx), "A")
-	// missing right val should be empty string
+	// missing right val should be null
 	if valIdx == -1 {
 		// try val_y
 		valIdx = colIndex(jl, "val_y")
@@ -333,7 +350,7 @@ func TestJoinLeftRightFull(t *testing.T) {
 			t.Fatalf("expected column 'val' or 'val_y' in join result, got %v", jl.Names())
 		}
 	}
-	assert.Equal(t, jl.At(row1, valIdx), "")
+	assert.Equal(t, jl.At(row1, valIdx), nil)
 
 	// Right join
 	jr := left.JoinRight(right, "id")
@@ -359,7 +376,7 @@ func TestJoinLeftRightFull(t *testing.T) {
 	if nameIdxR == -1 {
 		t.Fatalf("expected name column in right join result, got %v", jr.Names())
 	}
-	assert.Equal(t, jr.At(row3, nameIdxR), "")
+	assert.Equal(t, jr.At(row3, nameIdxR), nil)
 
 	// Full join
 	jf := left.JoinFull(right, "id")
@@ -433,3 +450,15 @@ func TestPivotAndUnpivot(t *testing.T) {
 	// columns id,key,val
 	assert.Equal(t, c2, 3)
 }
+
+func TestDataFrame_SetFormat(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b"}, series.String, "name"),
+		series.New([]float64{0.5, 1.0 / 3}, series.Float, "ratio"),
+	)
+	assert.Equal(t, df.String(), "   name               ratio\n0     a                 0.5\n1     b  0.3333333333333333")
+
+	df.SetFormat("ratio", series.NumberFormat{Precision: 2})
+	assert.Equal(t, df.String(), "   name  ratio\n0     a   0.50\n1     b   0.33")
+	assert.Equal(t, df.Head(1).String(), "   name  ratio\n0     a   0.50")
+}
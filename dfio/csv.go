@@ -152,7 +152,7 @@ func ToCSV(path string, df *golumn.DataFrame, settings ...CSVSettings) error {
 					rec[j] = ""
 				}
 			} else {
-				rec[j] = fmt.Sprint(df.At(i, j))
+				rec[j] = col.FormatVal(i)
 			}
 		}
 		if err := w.Write(rec); err != nil {
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn"
+	"github.com/chriso345/golumn/series"
 )
 
 func TestReadCSV(t *testing.T) {
@@ -74,3 +77,28 @@ func TestCSVNulls(t *testing.T) {
 	col := df.Columns()[1]
 	assert.Equal(t, col.CountNulls(), 2)
 }
+
+func TestWriteCSV_NumberFormat(t *testing.T) {
+	df := golumn.New(
+		series.New([]float64{0.000001234, 2.5}, series.Float, "small"),
+		series.New([]float64{1.0 / 3, 2}, series.Float, "ratio"),
+	)
+	df.SetFormat("ratio", series.NumberFormat{Precision: 3})
+
+	f, err := os.CreateTemp(".", "csv_format_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := f.Name()
+	f.Close()
+	defer os.Remove(name)
+
+	if err := ToCSV(name, &df); err != nil {
+		t.Fatalf("ToCSV error: %v", err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(data), "small,ratio\n1.234e-06,0.333\n2.5,2.000\n")
+}
@@ -54,7 +54,7 @@ func FromJSON(path string) *golumn.DataFrame {
 				se[j].Append(nil)
 				continue
 			}
-			se[j].Append(fmt.Sprint(v))
+			se[j].Append(series.FormatValue(v, series.NumberFormat{}))
 		}
 	}
 
@@ -76,7 +76,7 @@ func ToJSON(path string, df *golumn.DataFrame) error {
 	for i := range nrows {
 		rec := make(map[string]any, ncols)
 		for j, name := range names {
-			rec[name] = df.At(i, j)
+			rec[name] = jsonValue(df.Column(name), i, df.At(i, j))
 		}
 		arr[i] = rec
 	}
@@ -88,3 +88,21 @@ func ToJSON(path string, df *golumn.DataFrame) error {
 	}
 	return nil
 }
+
+// jsonValue returns the value to encode for row i of col. Numbers of a column with a
+// NumberFormat are written as formatted JSON numbers, or as strings if the format does not
+// produce a valid number.
+func jsonValue(col *series.Series, i int, v any) any {
+	if col.Format() == (series.NumberFormat{}) {
+		return v
+	}
+	switch v.(type) {
+	case int, float64:
+		s := col.FormatVal(i)
+		if json.Valid([]byte(s)) {
+			return json.Number(s)
+		}
+		return s
+	}
+	return v
+}
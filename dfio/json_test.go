@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn"
+	"github.com/chriso345/golumn/series"
 )
 
 func TestReadJSON(t *testing.T) {
@@ -70,3 +73,25 @@ func TestJSONNulls(t *testing.T) {
 	col := df.Columns()[1]
 	assert.Equal(t, col.CountNulls(), 2)
 }
+
+func TestWriteJSON_NumberFormat(t *testing.T) {
+	df := golumn.New(series.New([]float64{1.0 / 3}, series.Float, "ratio"))
+	df.SetFormat("ratio", series.NumberFormat{Precision: 2})
+
+	f, err := os.CreateTemp(".", "json_format_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := f.Name()
+	f.Close()
+	defer os.Remove(name)
+
+	if err := ToJSON(name, &df); err != nil {
+		t.Fatalf("ToJSON error: %v", err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(data), "[\n  {\n    \"ratio\": 0.33\n  }\n]\n")
+}
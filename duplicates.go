@@ -0,0 +1,57 @@
+package golumn
+
+import (
+	"github.com/chriso345/golumn/series"
+)
+
+// Keep selects which occurrence of a duplicated row is treated as the original.
+type Keep int
+
+const (
+	KeepFirst Keep = iota // the first occurrence is kept, later ones are duplicates
+	KeepLast              // the last occurrence is kept, earlier ones are duplicates
+	KeepNone              // every occurrence of a repeated row is a duplicate
+)
+
+// Duplicated returns a Boolean series marking rows whose values in subset repeat those of
+// another row, as selected by keep. A nil subset compares all columns. Nulls compare equal.
+func (df DataFrame) Duplicated(subset []string, keep Keep) series.Series {
+	mask := make([]bool, df.nrows)
+	if df.nrows == 0 {
+		return series.New(mask, series.Boolean, "duplicated")
+	}
+
+	cols := df.columns
+	if subset != nil {
+		cols = df.keyColumns(subset)
+	}
+
+	for _, rows := range newKeyIndex(cols, false).groups {
+		if len(rows) == 1 {
+			continue
+		}
+		for _, i := range rows {
+			mask[i] = true
+		}
+		switch keep {
+		case KeepFirst:
+			mask[rows[0]] = false
+		case KeepLast:
+			mask[rows[len(rows)-1]] = false
+		}
+	}
+	return series.New(mask, series.Boolean, "duplicated")
+}
+
+// DropDuplicates returns a new DataFrame without the rows that Duplicated marks, keeping the
+// index and order of the remaining rows.
+func (df DataFrame) DropDuplicates(subset []string, keep Keep) DataFrame {
+	dup := df.Duplicated(subset, keep)
+	rows := make([]int, 0, df.nrows)
+	for i := range df.nrows {
+		if !dup.Val(i).(bool) {
+			rows = append(rows, i)
+		}
+	}
+	return df.takeRows(rows)
+}
@@ -0,0 +1,50 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestDuplicated(t *testing.T) {
+	b := series.New([]string{"x", "x|~|y", "", "", "x"}, series.String, "b")
+	b.Elem(2).Set(nil)
+	b.Elem(3).Set(nil)
+	df := New(
+		series.New([]string{"a|~|x", "a", "a", "a", "a|~|x"}, series.String, "a"),
+		b,
+	)
+
+	first := df.Duplicated(nil, KeepFirst)
+	expected := []any{false, false, false, true, true}
+	for i := range expected {
+		assert.Equal(t, first.Val(i), expected[i])
+	}
+
+	last := df.Duplicated(nil, KeepLast)
+	expected = []any{true, false, true, false, false}
+	for i := range expected {
+		assert.Equal(t, last.Val(i), expected[i])
+	}
+
+	none := df.Duplicated([]string{"a"}, KeepNone)
+	expected = []any{true, true, true, true, true}
+	for i := range expected {
+		assert.Equal(t, none.Val(i), expected[i])
+	}
+}
+
+func TestDropDuplicates(t *testing.T) {
+	df := New(
+		series.New([]int{1, 1, 2, 1}, series.Int, "k"),
+		series.New([]float64{1.0, 1.0, 2.0, 3.0}, series.Float, "v"),
+	)
+
+	all := df.DropDuplicates(nil, KeepFirst)
+	assert.Equal(t, all.String(), "   k  v\n0  1  1\n2  2  2\n3  1  3")
+
+	byKey := df.DropDuplicates([]string{"k"}, KeepLast)
+	assert.Equal(t, byKey.String(), "   k  v\n2  2  2\n3  1  3")
+}
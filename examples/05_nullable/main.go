@@ -10,7 +10,7 @@ import (
 func main() {
 	// Demonstrate nulls and cleaning
 	s := series.New([]int{1, 2, 3}, series.Int, "vals")
-	s.Elem(1).Set(nil)
+	s.SetNull(1)
 
 	df := golumn.New(s, series.New([]string{"a", "b", "c"}, series.String, "letters"))
 
@@ -0,0 +1,127 @@
+package golumn
+
+import (
+	"fmt"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// FillMethod selects how DataFrame.FillNAMethod fills nulls from neighbouring rows.
+type FillMethod string
+
+const (
+	FillForward  FillMethod = "ffill" // carry the last non-null value forward
+	FillBackward FillMethod = "bfill" // carry the next non-null value backward
+)
+
+// DropHow selects which rows DataFrame.DropNA removes.
+type DropHow int
+
+const (
+	DropAny DropHow = iota // drop rows with a null in any of the columns
+	DropAll                // drop rows whose values are all null
+)
+
+// DropNAOptions defines optional settings for DataFrame.DropNA.
+type DropNAOptions struct {
+	Subset []string // columns to check for nulls; defaults to all columns
+	How    DropHow
+	Thresh int // if positive, keep rows with at least Thresh non-null values instead of using How
+}
+
+// FillNA returns a copy of the DataFrame with the nulls of each named column replaced by the
+// given value.
+func (df DataFrame) FillNA(values map[string]any) DataFrame {
+	for name := range values {
+		if df.columnIndex(name) == -1 {
+			panic(fmt.Errorf("column %v not found", name))
+		}
+	}
+
+	out := df.Copy()
+	for j, c := range out.columns {
+		if v, ok := values[c.Name]; ok {
+			out.columns[j] = c.FillNA(v)
+		}
+	}
+	return out
+}
+
+// FillNAMethod returns a copy of the DataFrame with the nulls of every column filled from
+// neighbouring rows. If limit is positive, at most limit consecutive nulls are filled.
+func (df DataFrame) FillNAMethod(method FillMethod, limit int) DataFrame {
+	out := df.Copy()
+	for j, c := range out.columns {
+		switch method {
+		case FillForward:
+			out.columns[j] = c.FillForward(limit)
+		case FillBackward:
+			out.columns[j] = c.FillBackward(limit)
+		default:
+			panic(fmt.Errorf("unknown fill method %q", method))
+		}
+	}
+	return out
+}
+
+// Interpolate returns a copy of the DataFrame with the nulls of each numeric column estimated
+// from their neighbours (see series.Series.Interpolate). series.InterpolateIndex spaces values
+// by the DataFrame index, which must then have a single level.
+func (df DataFrame) Interpolate(method series.InterpolateMethod) DataFrame {
+	if method == series.InterpolateIndex && df.index.NLevels() > 1 {
+		panic("index interpolation requires a single-level index")
+	}
+	var opts series.InterpolateOptions
+	if method == series.InterpolateIndex && df.index.NLevels() == 1 {
+		opts.Index = &df.index.levels[0]
+	}
+	return df.mapNumeric(func(s series.Series) series.Series { return s.Interpolate(method, opts) })
+}
+
+// DropNA removes rows with nulls and returns a new DataFrame. By default a row is dropped if
+// any column is null; DropNAOptions restricts the columns checked and how many nulls are
+// tolerated. The index labels of the remaining rows are kept.
+func (df DataFrame) DropNA(opts ...DropNAOptions) DataFrame {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var o DropNAOptions
+	if len(opts) == 1 {
+		o = opts[0]
+	}
+
+	cols := df.columns
+	if len(o.Subset) > 0 {
+		cols = df.keyColumns(o.Subset)
+	}
+
+	keep := make([]int, 0, df.nrows)
+	for i := range df.nrows {
+		valid := 0
+		for _, c := range cols {
+			if c.IsValid(i) {
+				valid++
+			}
+		}
+
+		switch {
+		case o.Thresh > 0:
+			if valid >= o.Thresh {
+				keep = append(keep, i)
+			}
+		case o.How == DropAll:
+			if valid > 0 {
+				keep = append(keep, i)
+			}
+		default:
+			if valid == len(cols) {
+				keep = append(keep, i)
+			}
+		}
+	}
+
+	if len(keep) == 0 {
+		return DataFrame{}
+	}
+	return df.takeRows(keep)
+}
@@ -0,0 +1,57 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestDataFrame_FillNA(t *testing.T) {
+	df := New(
+		series.New([]any{"a", nil, nil, "d"}, series.String, "name"),
+		series.New([]any{1.0, nil, 3.0, nil}, series.Float, "score"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "id"),
+	)
+
+	filled := df.FillNA(map[string]any{"name": "?", "score": 0})
+	assert.Equal(t, filled.String(), "   name  score  id\n0     a      1   1\n1     ?      0   2\n2     ?      3   3\n3     d      0   4")
+	assert.Equal(t, df.Column("name").IsNull(1), true)
+
+	forward := df.FillNAMethod(FillForward, 1)
+	assert.Equal(t, forward.String(), "   name  score  id\n0     a      1   1\n1     a      1   2\n2            3   3\n3     d      3   4")
+
+	backward := df.FillNAMethod(FillBackward, 0)
+	assert.Equal(t, backward.String(), "   name  score  id\n0     a      1   1\n1     d      3   2\n2     d      3   3\n3     d          4")
+
+	interpolated := df.Interpolate(series.InterpolateLinear)
+	assert.Equal(t, interpolated.Column("score").Val(1), any(2.0))
+	assert.Equal(t, interpolated.Column("score").IsNull(3), true)
+	assert.Equal(t, interpolated.Column("name").IsNull(1), true)
+
+	// a zero frame has no index and nothing to interpolate
+	empty := DataFrame{}.Interpolate(series.InterpolateLinear)
+	assert.Equal(t, len(empty.Names()), 0)
+
+	assert.Panic(t, func() { df.FillNA(map[string]any{"missing": 0}) })
+}
+
+func TestDataFrame_DropNA(t *testing.T) {
+	df := New(
+		series.New([]any{"a", nil, nil, "d"}, series.String, "name"),
+		series.New([]any{1.0, nil, 3.0, nil}, series.Float, "score"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "id"),
+	)
+
+	assert.Equal(t, df.DropNA().String(), "   name  score  id\n0     a      1   1")
+
+	subset := df.DropNA(DropNAOptions{Subset: []string{"score"}})
+	assert.Equal(t, subset.String(), "   name  score  id\n0     a      1   1\n2            3   3")
+
+	all := df.DropNA(DropNAOptions{Subset: []string{"name", "score"}, How: DropAll})
+	assert.Equal(t, all.Index().String(), "{Index [0 2 3] int}")
+
+	thresh := df.DropNA(DropNAOptions{Thresh: 2})
+	assert.Equal(t, thresh.Index().String(), "{Index [0 2 3] int}")
+}
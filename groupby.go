@@ -2,6 +2,7 @@ package golumn
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/chriso345/golumn/series"
@@ -9,32 +10,63 @@ import (
 
 // GroupBy represents a grouping on one or more columns.
 type GroupBy struct {
-	parent DataFrame
-	keys   []string
-	groups map[string][]int // map serialized key -> row positions
-	order  []string         // ordered list of group keys in first-seen order
+	parent  DataFrame
+	keys    []string
+	groups  [][]int // row positions of each group, in group order
+	tuples  [][]any // key values of each group, in group order
+	asIndex bool
 }
 
-// GroupBy creates a GroupBy object by specified key columns.
+// GroupByOptions defines optional settings for DataFrame.GroupByWith.
+type GroupByOptions struct {
+	DropNA bool // leave out rows with a null key instead of grouping them together
+	Sort   bool // order groups by key (ascending, nulls last) instead of first appearance
+
+	// AsIndex labels the rows returned by Aggregate with the group keys, one index level per
+	// key, instead of 0 to n-1.
+	AsIndex bool
+}
+
+// Group is a single group of a GroupBy: its key values, in key column order, and its rows.
+type Group struct {
+	Key   []any
+	Frame DataFrame
+}
+
+// GroupBy creates a GroupBy object by specified key columns. Groups are formed from the typed
+// key values, so rows with null keys form their own group, and are kept in first-seen order.
 func (df DataFrame) GroupBy(keys ...string) GroupBy {
+	return df.GroupByWith(GroupByOptions{}, keys...)
+}
+
+// GroupByWith creates a GroupBy object by specified key columns using the given options.
+func (df DataFrame) GroupByWith(opts GroupByOptions, keys ...string) GroupBy {
 	if len(keys) == 0 {
 		panic("no group by keys specified")
 	}
-	g := GroupBy{parent: df, keys: keys, groups: make(map[string][]int), order: make([]string, 0)}
-	for i := 0; i < df.nrows; i++ {
-		parts := make([]string, len(keys))
-		for j, k := range keys {
-			parts[j] = fmt.Sprint(df.Column(k).Val(i))
-		}
-		key := strings.Join(parts, "|~|")
-		if _, ok := g.groups[key]; !ok {
-			g.order = append(g.order, key)
-		}
-		g.groups[key] = append(g.groups[key], i)
+
+	index := newKeyIndex(df.keyColumns(keys), opts.DropNA)
+	order := make([]int, len(index.groups))
+	for g := range order {
+		order[g] = g
+	}
+	if opts.Sort {
+		order = index.sortedGroups()
+	}
+
+	g := GroupBy{parent: df, keys: keys, asIndex: opts.AsIndex}
+	for _, id := range order {
+		g.groups = append(g.groups, index.groups[id])
+		g.tuples = append(g.tuples, index.key(id))
 	}
 	return g
 }
 
+// frame returns the rows of group gi as a DataFrame, keeping the parent index labels.
+func (g GroupBy) frame(gi int) DataFrame {
+	return g.parent.takeRows(g.groups[gi])
+}
+
 // String implements fmt.Stringer for GroupBy in a pandas-like way: prints rows with group key
 // values shown once per consecutive group and hidden (empty) for duplicate consecutive rows.
 func (g GroupBy) String() string {
@@ -65,20 +97,22 @@ func (g GroupBy) String() string {
 		cols = append(cols, c)
 	}
 
-	// compute widths
+	// compute widths; index labels are printed in group order
+	var rows []int
+	for _, positions := range g.groups {
+		rows = append(rows, positions...)
+	}
+	labels := g.parent.index.printedLabels(rows)
 	maxIndexWidth := 0
-	for i := 0; i < g.parent.nrows; i++ {
-		w := len(fmt.Sprint(g.parent.index.Val(i)))
-		if w > maxIndexWidth {
-			maxIndexWidth = w
-		}
+	if len(labels) > 0 {
+		maxIndexWidth = len(labels[0])
 	}
 
 	colWidths := make([]int, len(cols))
 	for j, col := range cols {
 		maxW := len(col.Name)
 		for i := 0; i < g.parent.nrows; i++ {
-			v := fmt.Sprint(col.Val(i))
+			v := col.FormatVal(i)
 			if lw := len(v); lw > maxW {
 				maxW = lw
 			}
@@ -99,20 +133,18 @@ func (g GroupBy) String() string {
 	sb.WriteString("\n")
 
 	// print rows grouped by group order; hide duplicate group key values within each group
-	firstRow := true
-	for _, key := range g.order {
-		positions := g.groups[key]
+	r := 0
+	for _, positions := range g.groups {
 		for gi, pos := range positions {
-			if !firstRow {
+			if r > 0 {
 				sb.WriteString("\n")
 			}
-			firstRow = false
-			indexStr := fmt.Sprint(g.parent.index.Val(pos))
-			sb.WriteString(padLeft(indexStr, maxIndexWidth))
+			sb.WriteString(labels[r])
+			r++
 			sb.WriteString("  ")
 
 			for j := range cols {
-				val := fmt.Sprint(cols[j].Val(pos))
+				val := cols[j].FormatVal(pos)
 				if j < len(g.keys) && gi > 0 {
 					// hide duplicate key values for subsequent rows in the same group
 					sb.WriteString(padLeft("", colWidths[j]))
@@ -129,79 +161,38 @@ func (g GroupBy) String() string {
 	return sb.String()
 }
 
-// Groups returns a map of serialized group key -> DataFrame for that group.
-func (g GroupBy) Groups() map[string]DataFrame {
-	out := make(map[string]DataFrame)
-	for _, key := range g.order {
-		positions := g.groups[key]
-		subs := make([]series.Series, len(g.parent.columns))
-		for ci, col := range g.parent.columns {
-			switch col.Type() {
-			case series.Int:
-				subs[ci] = series.New(make([]int, len(positions)), series.Int, col.Name)
-			case series.Float:
-				subs[ci] = series.New(make([]float64, len(positions)), series.Float, col.Name)
-			case series.Boolean:
-				subs[ci] = series.New(make([]bool, len(positions)), series.Boolean, col.Name)
-			case series.String:
-				subs[ci] = series.New(make([]string, len(positions)), series.String, col.Name)
-			default:
-				panic(fmt.Errorf("unsupported series type: %v", col.Type()))
-			}
-		}
-		for ri, pos := range positions {
-			for ci := range subs {
-				subs[ci].Elem(ri).Set(g.parent.columns[ci].Val(pos))
-			}
-		}
-		out[key] = New(subs...)
+// Groups returns the groups with their key tuples, in group order.
+func (g GroupBy) Groups() []Group {
+	out := make([]Group, len(g.groups))
+	for gi := range g.groups {
+		out[gi] = Group{Key: g.tuples[gi], Frame: g.frame(gi)}
 	}
 	return out
 }
 
-// Aggregate applies aggregation functions to each group. aggs is a map from output name to func(rows DataFrame) series.Series
+// Aggregate applies aggregation functions to each group. agg receives the rows of each group and
+// should return a single-row DataFrame, or an empty one to leave the group out; the rows are
+// combined in group order. See GroupByOptions.AsIndex for how the rows are labelled.
 func (g GroupBy) Aggregate(agg func(df DataFrame) DataFrame) DataFrame {
 	first := true
 	var out DataFrame
-	for _, key := range g.order {
-		positions := g.groups[key]
-		// build a sub-DataFrame for the group
-		subs := make([]series.Series, len(g.parent.columns))
-		for ci, col := range g.parent.columns {
-			switch col.Type() {
-			case series.Int:
-				subs[ci] = series.New(make([]int, len(positions)), series.Int, col.Name)
-			case series.Float:
-				subs[ci] = series.New(make([]float64, len(positions)), series.Float, col.Name)
-			case series.Boolean:
-				subs[ci] = series.New(make([]bool, len(positions)), series.Boolean, col.Name)
-			case series.String:
-				subs[ci] = series.New(make([]string, len(positions)), series.String, col.Name)
-			default:
-				panic(fmt.Errorf("unsupported series type: %v", col.Type()))
-			}
-		}
-		for ri, pos := range positions {
-			for ci := range subs {
-				subs[ci].Elem(ri).Set(g.parent.columns[ci].Val(pos))
-			}
-		}
-		subdf := New(subs...)
+	var kept []int
+	for gi := range g.groups {
 		// apply agg which should return a DataFrame with a single row
-		res := agg(subdf)
+		res := agg(g.frame(gi))
 		if res.nrows == 0 {
 			continue
 		}
+		if res.nrows != 1 {
+			panic("aggregate function must return a single-row DataFrame per group")
+		}
+		kept = append(kept, gi)
 		if first {
 			// initialize out with res copy
 			out = res.Copy()
 			first = false
 			continue
 		}
-		// append row-wise: res is expected to be single-row DataFrame
-		if res.nrows != 1 {
-			panic("aggregate function must return a single-row DataFrame per group")
-		}
 		// append each value to the corresponding column of out
 		for ci := range res.columns {
 			out.columns[ci].Append(res.columns[ci].Val(0))
@@ -211,5 +202,103 @@ func (g GroupBy) Aggregate(agg func(df DataFrame) DataFrame) DataFrame {
 	if first {
 		return DataFrame{}
 	}
+
+	if !g.asIndex {
+		out.index = rangeIndex(out.nrows)
+		return out
+	}
+	keys := g.parent.keyColumns(g.keys)
+	levels := make([]series.Series, len(keys))
+	for k, key := range keys {
+		vals := make([]any, len(kept))
+		for i, gi := range kept {
+			vals[i] = g.tuples[gi][k]
+		}
+		levels[k] = series.New(vals, key.Type(), key.Name)
+	}
+	out.index = newIndex(levels...)
 	return out
 }
+
+// Transform applies fn to the values of column col within each group and returns a series
+// aligned with the rows of the parent DataFrame. fn must return a series of the same length as
+// its input, or of length 1 to broadcast a single value over the group. Rows that belong to no
+// group are null; results of different types are promoted to a common type.
+func (g GroupBy) Transform(col string, fn func(s series.Series) series.Series) series.Series {
+	src := *g.parent.Column(col)
+	vals := make([]any, g.parent.nrows)
+	var types []series.Type
+	for _, positions := range g.groups {
+		res := fn(src.Take(positions))
+		if res.Len() != len(positions) && res.Len() != 1 {
+			panic(fmt.Errorf("transform returned %v values for a group of %v rows", res.Len(), len(positions)))
+		}
+		types = append(types, res.Type())
+		for k, pos := range positions {
+			if res.Len() == 1 {
+				vals[pos] = res.Val(0)
+			} else {
+				vals[pos] = res.Val(k)
+			}
+		}
+	}
+
+	t := src.Type()
+	if len(types) > 0 {
+		t = series.CommonType(types...)
+	}
+	return series.New(vals, t, col)
+}
+
+// Filter returns the rows of every group for which fn returns true, in their original order.
+func (g GroupBy) Filter(fn func(df DataFrame) bool) DataFrame {
+	keep := make([]bool, g.parent.nrows)
+	for gi, positions := range g.groups {
+		if !fn(g.frame(gi)) {
+			continue
+		}
+		for _, pos := range positions {
+			keep[pos] = true
+		}
+	}
+
+	var rows []int
+	for i, k := range keep {
+		if k {
+			rows = append(rows, i)
+		}
+	}
+	return g.parent.takeRows(rows)
+}
+
+// Apply calls fn on each group and concatenates the results in group order (see Concat), with
+// the group key columns prepended to every result row. Key columns returned by fn are replaced.
+func (g GroupBy) Apply(fn func(df DataFrame) DataFrame) DataFrame {
+	keyCols := g.parent.keyColumns(g.keys)
+	var parts []DataFrame
+	for gi := range g.groups {
+		res := fn(g.frame(gi))
+		if res.ncols == 0 {
+			continue
+		}
+
+		cols := make([]series.Series, 0, len(g.keys)+res.ncols)
+		for k, kc := range keyCols {
+			vals := make([]any, res.nrows)
+			for i := range vals {
+				vals[i] = g.tuples[gi][k]
+			}
+			cols = append(cols, series.New(vals, kc.Type(), kc.Name))
+		}
+		for _, c := range res.columns {
+			if !slices.Contains(g.keys, c.Name) {
+				cols = append(cols, c)
+			}
+		}
+
+		part := New(cols...)
+		part.index = res.index
+		parts = append(parts, part)
+	}
+	return Concat(parts)
+}
@@ -21,10 +21,12 @@ func TestGroupBy_StringAndGroups(t *testing.T) {
 	assert.Equal(t, gb.String(), expected)
 
 	groups := gb.Groups()
-	// two groups: "a" and "b"
+	// two groups: "a" and "b", in first-seen order
 	assert.Equal(t, len(groups), 2)
+	assert.Equal(t, groups[0].Key[0], "a")
+	assert.Equal(t, groups[1].Key[0], "b")
 
-	gA := groups["a"]
+	gA := groups[0].Frame
 	rows, cols := gA.Shape()
 	assert.Equal(t, rows, 2)
 	assert.Equal(t, cols, 2)
@@ -66,6 +68,36 @@ func TestGroupBy_Aggregate(t *testing.T) {
 	valCol := res.Column("Val")
 	assert.Equal(t, valCol.Val(0), 9) // 1+3+5
 	assert.Equal(t, valCol.Val(1), 6) // 2+4
+
+	assert.Equal(t, res.Index().Val(1), 1)
+
+	// with AsIndex the group keys label the rows
+	keyed := df.GroupByWith(GroupByOptions{AsIndex: true}, "Key").Aggregate(agg)
+	assert.Equal(t, keyed.Index().Name, "Key")
+	assert.Equal(t, keyed.Loc("y").At(0, 1), 6)
+
+	multi := New(
+		series.New([]string{"a", "a", "b"}, series.String, "K1"),
+		series.New([]int{1, 2, 1}, series.Int, "K2"),
+		series.New([]int{10, 20, 30}, series.Int, "Val"),
+	).GroupByWith(GroupByOptions{AsIndex: true}, "K1", "K2").Aggregate(func(d DataFrame) DataFrame {
+		return New(series.New([]float64{d.Column("Val").Mean()}, series.Float, "Val"))
+	})
+	idx := multi.MultiIndex()
+	assert.Equal(t, idx.NLevels(), 2)
+	assert.Equal(t, idx.Level(1).Type(), series.Int)
+	assert.Equal(t, idx.Level(1).Val(1), 2)
+	assert.Equal(t, multi.Column("Val").Val(2), 30.0)
+
+	// every group, the first one included, must give a single row
+	assert.Panic(t, func() {
+		gb.Aggregate(func(d DataFrame) DataFrame {
+			if d.Column("Key").Val(0) == "x" {
+				return d
+			}
+			return agg(d)
+		})
+	})
 }
 
 func TestGroupBy_AggregateEmptyAndPanic(t *testing.T) {
@@ -89,7 +121,7 @@ func TestGroupBy_AggregateEmptyAndPanic(t *testing.T) {
 }
 
 func TestGroupBy_MultiKeyGroups(t *testing.T) {
-	// group by two keys and verify serialized group keys and group DataFrames
+	// group by two keys and verify typed group keys and group DataFrames
 	df := New(
 		series.New([]string{"a", "a", "b", "b"}, series.String, "K1"),
 		series.New([]int{1, 1, 2, 2}, series.Int, "K2"),
@@ -97,31 +129,127 @@ func TestGroupBy_MultiKeyGroups(t *testing.T) {
 	)
 	gb := df.GroupBy("K1", "K2")
 	groups := gb.Groups()
-	// expect two groups: a|~|1 and b|~|2
+	// expect two groups: (a, 1) and (b, 2)
 	if len(groups) != 2 {
 		t.Fatalf("expected 2 groups, got %d", len(groups))
 	}
 	// validate groups
-	if g, ok := groups["a|~|1"]; ok {
-		if rows, _ := g.Shape(); rows != 2 {
-			t.Fatalf("expected 2 rows in group a|~|1, got %d", rows)
+	expected := []struct {
+		k1   string
+		k2   int
+		vals []int
+	}{
+		{"a", 1, []int{10, 20}},
+		{"b", 2, []int{30, 40}},
+	}
+	for gi, e := range expected {
+		g := groups[gi]
+		if g.Key[0] != e.k1 || g.Key[1] != e.k2 {
+			t.Fatalf("unexpected key for group %d: %v", gi, g.Key)
 		}
-		val := g.Column("Val")
-		if val.Val(0) != 10 || val.Val(1) != 20 {
-			t.Fatalf("unexpected vals for group a|~|1: %v,%v", val.Val(0), val.Val(1))
+		if rows, _ := g.Frame.Shape(); rows != 2 {
+			t.Fatalf("expected 2 rows in group %v, got %d", g.Key, rows)
 		}
-	} else {
-		t.Fatalf("group a|~|1 missing")
-	}
-	if g, ok := groups["b|~|2"]; ok {
-		if rows, _ := g.Shape(); rows != 2 {
-			t.Fatalf("expected 2 rows in group b|~|2, got %d", rows)
+		val := g.Frame.Column("Val")
+		if val.Val(0) != e.vals[0] || val.Val(1) != e.vals[1] {
+			t.Fatalf("unexpected vals for group %v: %v,%v", g.Key, val.Val(0), val.Val(1))
 		}
-		val := g.Column("Val")
-		if val.Val(0) != 30 || val.Val(1) != 40 {
-			t.Fatalf("unexpected vals for group b|~|2: %v,%v", val.Val(0), val.Val(1))
+	}
+}
+
+func TestGroupBy_KeysDoNotCollide(t *testing.T) {
+	k1 := series.New([]string{"a|~|b", "a", "<nil>", ""}, series.String, "K1")
+	k1.Elem(3).Set(nil)
+	df := New(
+		k1,
+		series.New([]string{"c", "b|~|c", "x", "x"}, series.String, "K2"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "Val"),
+	)
+
+	groups := df.GroupBy("K1", "K2").Groups()
+	assert.Equal(t, len(groups), 4)
+	assert.Equal(t, groups[3].Key[0], nil)
+	assert.Equal(t, groups[3].Key[1], "x")
+
+	dropped := df.GroupByWith(GroupByOptions{DropNA: true}, "K1", "K2").Groups()
+	assert.Equal(t, len(dropped), 3)
+}
+
+func TestGroupBy_Sort(t *testing.T) {
+	k := series.New([]int{3, 1, 0, 2, 1}, series.Int, "K")
+	k.Elem(2).Set(nil)
+	df := New(k, series.New([]int{1, 2, 3, 4, 5}, series.Int, "Val"))
+
+	groups := df.GroupByWith(GroupByOptions{Sort: true}, "K").Groups()
+	expected := []any{1, 2, 3, nil}
+	assert.Equal(t, len(groups), len(expected))
+	for gi := range expected {
+		assert.Equal(t, groups[gi].Key[0], expected[gi])
+	}
+	// groups keep the parent index labels
+	assert.Equal(t, groups[0].Frame.Index().Val(1), 4)
+}
+
+func TestGroupBy_Transform(t *testing.T) {
+	df := New(
+		series.New([]string{"x", "y", "x", "y", "x"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 6, 5}, series.Int, "Val"),
+	)
+	gb := df.GroupBy("Key")
+
+	demeaned := gb.Transform("Val", func(s series.Series) series.Series {
+		mean := s.Mean()
+		out := series.NewEmptySeries(series.Float, s.Len(), s.Name)
+		for i := range s.Len() {
+			out.Elem(i).Set(float64(s.Val(i).(int)) - mean)
 		}
-	} else {
-		t.Fatalf("group b|~|2 missing")
+		return out
+	})
+	expected := []any{-2.0, -2.0, 0.0, 2.0, 2.0}
+	assert.Equal(t, demeaned.Len(), len(expected))
+	for i := range expected {
+		assert.Equal(t, demeaned.Val(i), expected[i])
 	}
+
+	sizes := gb.Transform("Val", func(s series.Series) series.Series {
+		return series.New([]int{s.Len()}, series.Int, "n")
+	})
+	assert.Equal(t, sizes.Type(), series.Int)
+	assert.Equal(t, sizes.Val(0), 3)
+	assert.Equal(t, sizes.Val(3), 2)
+}
+
+func TestGroupBy_Filter(t *testing.T) {
+	df := New(
+		series.New([]string{"x", "y", "x", "z", "x"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Val"),
+	)
+
+	big := df.GroupBy("Key").Filter(func(d DataFrame) bool {
+		r, _ := d.Shape()
+		return r > 1 || d.Column("Val").Val(0).(int) > 3
+	})
+	assert.Equal(t, big.String(), "   Key  Val\n0    x    1\n2    x    3\n3    z    4\n4    x    5")
+}
+
+func TestGroupBy_Apply(t *testing.T) {
+	df := New(
+		series.New([]string{"x", "y", "x", "y", "x"}, series.String, "Key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "Val"),
+	)
+
+	top := df.GroupBy("Key").Apply(func(d DataFrame) DataFrame {
+		r, _ := d.Shape()
+		return d.Slice(r-1, r)
+	})
+	assert.Equal(t, top.String(), "   Key  Val\n4    x    5\n3    y    4")
+
+	stats := df.GroupBy("Key").Apply(func(d DataFrame) DataFrame {
+		r, _ := d.Shape()
+		return New(
+			series.New([]int{r}, series.Int, "n"),
+			series.New([]float64{d.Column("Val").Mean()}, series.Float, "mean"),
+		)
+	})
+	assert.Equal(t, stats.String(), "   Key  n  mean\n0    x  3     3\n0    y  2     3")
 }
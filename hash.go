@@ -0,0 +1,219 @@
+package golumn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// keySeed is shared by all key hashes so that indexes built from different DataFrames agree.
+var keySeed = maphash.MakeSeed()
+
+// keyIndex groups row positions by the typed values of one or more key columns.
+// Keys are compared by value rather than by their printed form, so 1 and 1.0 match,
+// while "1" and 1, or nil and "<nil>", do not.
+type keyIndex struct {
+	cols    []series.Series
+	buckets map[uint64][]int // key hash -> group ids
+	groups  [][]int          // group id -> row positions, groups in first-seen order
+}
+
+// newKeyIndex builds a keyIndex over cols. If skipNulls is true, rows with a null in any key
+// column are left out; otherwise nulls compare equal to each other and form their own groups.
+func newKeyIndex(cols []series.Series, skipNulls bool) *keyIndex {
+	k := &keyIndex{cols: cols, buckets: make(map[uint64][]int)}
+	if len(cols) == 0 {
+		return k
+	}
+
+	for i := range cols[0].Len() {
+		if skipNulls && anyNullKey(cols, i) {
+			continue
+		}
+		h := hashKey(cols, i)
+		g := k.findHashed(h, cols, i)
+		if g == -1 {
+			g = len(k.groups)
+			k.groups = append(k.groups, nil)
+			k.buckets[h] = append(k.buckets[h], g)
+		}
+		k.groups[g] = append(k.groups[g], i)
+	}
+	return k
+}
+
+// find returns the group id whose key equals row i of cols, or -1 if there is none.
+func (k *keyIndex) find(cols []series.Series, i int) int {
+	return k.findHashed(hashKey(cols, i), cols, i)
+}
+
+func (k *keyIndex) findHashed(h uint64, cols []series.Series, i int) int {
+	for _, g := range k.buckets[h] {
+		if keysEqual(k.cols, k.groups[g][0], cols, i) {
+			return g
+		}
+	}
+	return -1
+}
+
+// lookup returns the group id whose key equals the tuple key, or -1 if there is none.
+func (k *keyIndex) lookup(key []any) int {
+	for _, g := range k.buckets[hashValues(key)] {
+		if keyEquals(k.cols, k.groups[g][0], key) {
+			return g
+		}
+	}
+	return -1
+}
+
+// key returns the key tuple of group g.
+func (k *keyIndex) key(g int) []any {
+	out := make([]any, len(k.cols))
+	for j, c := range k.cols {
+		out[j] = c.Val(k.groups[g][0])
+	}
+	return out
+}
+
+// rowGroups maps each of the n rows to its group id, or -1 for rows left out of the index.
+func (k *keyIndex) rowGroups(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = -1
+	}
+	for g, rows := range k.groups {
+		for _, i := range rows {
+			out[i] = g
+		}
+	}
+	return out
+}
+
+// sortedGroups returns the group ids ordered by key (ascending, nulls last).
+func (k *keyIndex) sortedGroups() []int {
+	ids := make([]int, len(k.groups))
+	for g := range ids {
+		ids[g] = g
+	}
+	slices.SortStableFunc(ids, func(a, b int) int {
+		return compareRows(k.cols, k.groups[a][0], k.groups[b][0])
+	})
+	return ids
+}
+
+// anyNullKey reports whether row i has a null in any of cols.
+func anyNullKey(cols []series.Series, i int) bool {
+	for _, c := range cols {
+		if c.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashKey hashes the typed key values of row i across cols.
+func hashKey(cols []series.Series, i int) uint64 {
+	var h maphash.Hash
+	h.SetSeed(keySeed)
+	for _, c := range cols {
+		writeKeyValue(&h, c.Val(i))
+	}
+	return h.Sum64()
+}
+
+// hashValues hashes a key tuple so that it matches hashKey for a row holding the same values.
+func hashValues(key []any) uint64 {
+	var h maphash.Hash
+	h.SetSeed(keySeed)
+	for _, v := range key {
+		writeKeyValue(&h, v)
+	}
+	return h.Sum64()
+}
+
+// writeKeyValue adds a single key value to h.
+func writeKeyValue(h *maphash.Hash, v any) {
+	var buf [8]byte
+	switch v := v.(type) {
+	case nil:
+		h.WriteByte(0)
+	case int:
+		// numbers hash by float value so that int and float keys can match
+		h.WriteByte(1)
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(float64(v)))
+		h.Write(buf[:])
+	case float64:
+		if v == 0 {
+			v = 0 // fold -0 into 0
+		}
+		h.WriteByte(1)
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	case bool:
+		h.WriteByte(2)
+		if v {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case string:
+		h.WriteByte(3)
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(v)))
+		h.Write(buf[:])
+		h.WriteString(v)
+	case time.Time:
+		// hash the instant so that equal times in different locations match
+		h.WriteByte(5)
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Unix()))
+		h.Write(buf[:])
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Nanosecond()))
+		h.Write(buf[:])
+	default:
+		h.WriteByte(4)
+		h.WriteString(fmt.Sprint(v))
+	}
+}
+
+// keysEqual reports whether row i of a and row j of b hold equal key tuples.
+func keysEqual(a []series.Series, i int, b []series.Series, j int) bool {
+	for c := range a {
+		if !valuesEqual(a[c].Val(i), b[c].Val(j)) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyEquals reports whether row i of cols holds the key tuple key.
+func keyEquals(cols []series.Series, i int, key []any) bool {
+	for c := range cols {
+		if !valuesEqual(cols[c].Val(i), key[c]) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual compares two values, treating int and float64 as comparable numbers and
+// datetimes as equal when they denote the same instant.
+func valuesEqual(a, b any) bool {
+	switch x := a.(type) {
+	case time.Time:
+		y, ok := b.(time.Time)
+		return ok && x.Equal(y)
+	case int:
+		if y, ok := b.(float64); ok {
+			return float64(x) == y
+		}
+	case float64:
+		if y, ok := b.(int); ok {
+			return x == float64(y)
+		}
+	}
+	return a == b
+}
@@ -0,0 +1,32 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestKeyIndex_TypedKeys(t *testing.T) {
+	s := series.New([]string{"a|~|b", "a", "<nil>", "", "a|~|b"}, series.String, "s")
+	s.Elem(3).Set(nil)
+	k := newKeyIndex([]series.Series{s}, false)
+
+	// "<nil>" and null are distinct groups, duplicates share one
+	assert.Equal(t, len(k.groups), 4)
+	assert.Equal(t, len(k.groups[0]), 2)
+	assert.Equal(t, k.key(3)[0], nil)
+
+	skipped := newKeyIndex([]series.Series{s}, true)
+	assert.Equal(t, len(skipped.groups), 3)
+}
+
+func TestKeyIndex_NumericKeysMatchAcrossTypes(t *testing.T) {
+	ints := []series.Series{series.New([]int{1, 2}, series.Int, "i")}
+	floats := []series.Series{series.New([]float64{2.0, 2.5}, series.Float, "f")}
+
+	k := newKeyIndex(ints, true)
+	assert.Equal(t, k.find(floats, 0), 1)
+	assert.Equal(t, k.find(floats, 1), -1)
+}
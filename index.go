@@ -0,0 +1,194 @@
+package golumn
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// indexLookup holds the hash index over the labels of a DataFrame index. It is shared by copies
+// of the DataFrame, built on the first label lookup and dropped when the rows are reordered.
+type indexLookup struct {
+	mu     sync.Mutex
+	keys   *keyIndex
+	sorted bool // outermost labels are ascending, with any nulls last
+}
+
+// reset drops the cached index so that the next lookup rebuilds it.
+func (l *indexLookup) reset() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = nil
+}
+
+// labelIndex returns the hash index over the index labels, building it on first use, and
+// whether the labels of the outermost level are sorted. A DataFrame without a lookup is not
+// cached; that is only the zero DataFrame, whose index is empty.
+func (df DataFrame) labelIndex() (*keyIndex, bool) {
+	l := df.lookup
+	if l == nil {
+		l = &indexLookup{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.keys == nil {
+		l.keys = newKeyIndex(df.index.levels, false)
+		l.sorted = true
+		outer := df.index.levels[:1]
+		for i := 1; i < df.nrows && l.sorted; i++ {
+			l.sorted = compareRows(outer, i-1, i) <= 0
+		}
+	}
+	return l.keys, l.sorted
+}
+
+// Loc returns the rows whose index label equals label, in their original order. Labels match by
+// value, so 1 and 1.0 are the same label, and a nil label selects the rows with a null label.
+// For a MultiIndex, label is either a []any tuple with one value per level or a label of the
+// outermost level. It panics if no row has the label.
+func (df DataFrame) Loc(label any) DataFrame {
+	key, full := df.labelKey(label)
+	if !full {
+		rows := df.levelRows(0, label)
+		if len(rows) == 0 {
+			panic(fmt.Errorf("label %v not found in index", label))
+		}
+		return df.takeRows(rows)
+	}
+
+	keys, _ := df.labelIndex()
+	g := keys.lookup(key)
+	if g == -1 {
+		panic(fmt.Errorf("label %v not found in index", label))
+	}
+	return df.takeRows(keys.groups[g])
+}
+
+// labelKey returns label as a key tuple over all index levels, or false if label is a value of
+// the outermost level of a MultiIndex.
+func (df DataFrame) labelKey(label any) ([]any, bool) {
+	if df.index.NLevels() == 1 {
+		return []any{label}, true
+	}
+	key, ok := label.([]any)
+	return key, ok && len(key) == df.index.NLevels()
+}
+
+// LocRange returns the rows whose index label lies between from and to, both included. A nil
+// bound leaves that end of the range open; rows with a null label are never selected. The index
+// must be sorted in ascending order, as after Sort on it, and the bounds need not be labels.
+// For a MultiIndex the bounds are compared with the outermost level.
+func (df DataFrame) LocRange(from, to any) DataFrame {
+	if _, sorted := df.labelIndex(); !sorted {
+		panic("LocRange requires an index sorted in ascending order")
+	}
+
+	// nulls sort last, so the labels in [0, n) are all non-null
+	outer := df.index.levels[0]
+	n := df.nrows - outer.CountNulls()
+	lo, hi := 0, n
+	if from != nil {
+		lo = sort.Search(n, func(i int) bool { return compareValues(outer.Val(i), from) >= 0 })
+	}
+	if to != nil {
+		hi = sort.Search(n, func(i int) bool { return compareValues(outer.Val(i), to) > 0 })
+	}
+	return df.takeRows(positionRange(lo, max(lo, hi)))
+}
+
+// ILoc returns the rows and columns at the given positions, in the order given. A nil rows or
+// cols selects all of them. The index labels of the selected rows are kept.
+func (df DataFrame) ILoc(rows, cols []int) DataFrame {
+	if rows == nil {
+		rows = positionRange(0, df.nrows)
+	}
+	if cols == nil {
+		cols = positionRange(0, df.ncols)
+	}
+	for _, i := range rows {
+		if i < 0 || i >= df.nrows {
+			panic(fmt.Errorf("row %v out of range", i))
+		}
+	}
+
+	sub := df
+	sub.columns = make([]series.Series, len(cols))
+	for k, j := range cols {
+		if j < 0 || j >= df.ncols {
+			panic(fmt.Errorf("column %v out of range", j))
+		}
+		sub.columns[k] = df.columns[j]
+	}
+	sub.ncols = len(cols)
+	return sub.takeRows(rows)
+}
+
+// Reindex returns a DataFrame with one row per label, in the order given, holding the row of df
+// with that index label. Labels missing from the index give rows with every column set to fill,
+// or to null if fill is nil; fill must be storable in every column. For a MultiIndex each label
+// is a []any tuple with one value per level. It panics if the index has duplicate labels.
+func (df DataFrame) Reindex(labels []any, fill any) DataFrame {
+	keys, _ := df.labelIndex()
+	if len(keys.groups) < df.nrows {
+		panic("cannot reindex an index with duplicate labels")
+	}
+
+	nlevels := df.index.NLevels()
+	positions := make([]int, len(labels))
+	levelVals := make([][]any, nlevels)
+	levelTypes := make([][]series.Type, nlevels)
+	for k, l := range df.index.levels {
+		levelVals[k] = make([]any, len(labels))
+		levelTypes[k] = []series.Type{l.Type()}
+	}
+	for i, label := range labels {
+		key, full := df.labelKey(label)
+		if !full {
+			panic(fmt.Errorf("label %v is not a tuple of %v index values", label, nlevels))
+		}
+		positions[i] = -1
+		if g := keys.lookup(key); g != -1 {
+			positions[i] = keys.groups[g][0]
+		}
+		for k, v := range key {
+			levelVals[k][i] = v
+			if v != nil {
+				levelTypes[k] = append(levelTypes[k], series.InferType(v))
+			}
+		}
+	}
+
+	out := df.takeRows(positions)
+	if fill != nil {
+		for _, c := range out.columns {
+			for i, pos := range positions {
+				if pos != -1 {
+					continue
+				}
+				if err := c.SetAt(i, fill); err != nil {
+					panic(err)
+				}
+			}
+		}
+	}
+	levels := make([]series.Series, nlevels)
+	for k, l := range df.index.levels {
+		levels[k] = series.New(levelVals[k], series.CommonType(levelTypes[k]...), l.Name)
+	}
+	out.index = newIndex(levels...)
+	return out
+}
+
+// positionRange returns the positions from lo up to, but not including, hi.
+func positionRange(lo, hi int) []int {
+	positions := make([]int, hi-lo)
+	for i := range positions {
+		positions[i] = lo + i
+	}
+	return positions
+}
@@ -0,0 +1,118 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestDataFrame_Loc(t *testing.T) {
+	df := New(
+		series.New([]int{10, 20, 30, 40}, series.Int, "Value"),
+		series.New([]string{"a", "b", "c", "d"}, series.String, "Name"),
+	).SetIndex(series.New([]string{"x", "y", "x", "z"}, series.String, "ID"))
+
+	row := df.Loc("y")
+	assert.Equal(t, row.nrows, 1)
+	assert.Equal(t, row.At(0, 0), 20)
+	assert.Equal(t, row.Index().Val(0), "y")
+
+	dup := df.Loc("x")
+	assert.Equal(t, dup.nrows, 2)
+	assert.Equal(t, dup.Column("Name").Val(0), "a")
+	assert.Equal(t, dup.Column("Name").Val(1), "c")
+
+	assert.Panic(t, func() { df.Loc("w") })
+
+	// numeric labels match by value
+	ints := New(series.New([]int{1, 2, 3}, series.Int, "A")).SetIndex(series.New([]int{5, 6, 7}, series.Int, "K"))
+	assert.Equal(t, ints.Loc(6.0).At(0, 0), 2)
+}
+
+func TestDataFrame_LocAfterReorder(t *testing.T) {
+	df := New(
+		series.New([]int{10, 20, 30, 40}, series.Int, "Value"),
+		series.New([]string{"a", "b", "c", "d"}, series.String, "Name"),
+	).SetIndex(series.New([]string{"x", "y", "x", "z"}, series.String, "ID"))
+	assert.Equal(t, df.Loc("z").At(0, 0), 40)
+
+	// reordering rows in place must not leave the cached lookup stale
+	df.Sort("Value")
+	df.Swap(0, 3)
+	assert.Equal(t, df.Loc("z").At(0, 0), 40)
+	assert.Equal(t, df.Loc("z").Column("Name").Val(0), "d")
+
+	// changing a returned index does not reach the frame or its lookup
+	idx := df.Index()
+	idx.SetNull(0)
+	df.MultiIndex().Level(0).SetNull(1)
+	assert.Equal(t, df.Index().IsNull(0), false)
+	assert.Equal(t, df.Loc(df.Index().Val(0)).nrows, 1)
+
+	// a new index gets its own lookup, leaving the original frame intact
+	other := df.SetIndex(series.New([]string{"p", "q", "r", "s"}, series.String, "ID"))
+	assert.Equal(t, other.Loc("p").At(0, 0), 40)
+	assert.Equal(t, df.Loc("z").At(0, 0), 40)
+}
+
+func TestDataFrame_LocRange(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "A"),
+	).SetIndex(series.New([]any{10, 20, 20, 40, nil}, series.Int, "K"))
+
+	r := df.LocRange(15, 40)
+	assert.Equal(t, r.nrows, 3)
+	assert.Equal(t, r.At(0, 0), 2)
+	assert.Equal(t, r.At(2, 0), 4)
+
+	assert.Equal(t, df.LocRange(nil, 20).nrows, 3)
+	assert.Equal(t, df.LocRange(20, nil).nrows, 3)
+	assert.Equal(t, df.LocRange(nil, nil).nrows, 4)
+	assert.Equal(t, df.LocRange(41, 50).nrows, 0)
+
+	unsorted := df.SetIndex(series.New([]any{40, 10, 20, 20, 30}, series.Int, "K"))
+	assert.Panic(t, func() { unsorted.LocRange(10, 20) })
+}
+
+func TestDataFrame_ILoc(t *testing.T) {
+	df := New(
+		series.New([]int{10, 20, 30, 40}, series.Int, "Value"),
+		series.New([]string{"a", "b", "c", "d"}, series.String, "Name"),
+	).SetIndex(series.New([]string{"x", "y", "x", "z"}, series.String, "ID"))
+
+	sub := df.ILoc([]int{3, 0}, []int{1})
+	assert.Equal(t, sub.nrows, 2)
+	assert.Equal(t, sub.ncols, 1)
+	assert.Equal(t, sub.At(0, 0), "d")
+	assert.Equal(t, sub.At(1, 0), "a")
+	assert.Equal(t, sub.Index().Val(0), "z")
+
+	all := df.ILoc(nil, nil)
+	assert.Equal(t, all.String(), df.String())
+
+	assert.Panic(t, func() { df.ILoc([]int{4}, nil) })
+	assert.Panic(t, func() { df.ILoc(nil, []int{2}) })
+}
+
+func TestDataFrame_Reindex(t *testing.T) {
+	df := New(
+		series.New([]int{10, 20, 30}, series.Int, "Value"),
+	).SetIndex(series.New([]string{"a", "b", "c"}, series.String, "ID"))
+
+	r := df.Reindex([]any{"c", "q", "a"}, nil)
+	assert.Equal(t, r.nrows, 3)
+	assert.Equal(t, r.At(0, 0), 30)
+	assert.Equal(t, r.Column("Value").IsNull(1), true)
+	assert.Equal(t, r.At(2, 0), 10)
+	assert.Equal(t, r.Index().Val(1), "q")
+	assert.Equal(t, r.Loc("q").nrows, 1)
+
+	filled := df.Reindex([]any{"q", "b"}, 0)
+	assert.Equal(t, filled.At(0, 0), 0)
+	assert.Equal(t, filled.At(1, 0), 20)
+
+	dup := df.SetIndex(series.New([]string{"a", "a", "c"}, series.String, "ID"))
+	assert.Panic(t, func() { dup.Reindex([]any{"a"}, nil) })
+}
@@ -0,0 +1,62 @@
+// Package topn selects the best n of a set of row positions with a bounded heap. It is shared
+// by the series and DataFrame methods that return the largest or smallest rows.
+package topn
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// Positions returns up to n of the positions 0 to size-1 for which keep is true, best first,
+// where compare(a, b) > 0 means a ranks ahead of b. Ties keep their order of appearance. It keeps
+// a heap of n candidates rather than sorting every position.
+func Positions(n, size int, keep func(i int) bool, compare func(a, b int) int) []int {
+	if n < 0 {
+		panic(fmt.Errorf("n must not be negative, got %v", n))
+	}
+
+	// better reports whether position a ranks ahead of b; earlier positions win ties
+	better := func(a, b int) bool {
+		if r := compare(a, b); r != 0 {
+			return r > 0
+		}
+		return a < b
+	}
+
+	// the root of the heap is the worst candidate kept so far
+	h := &Heap{Before: func(a, b int) bool { return better(b, a) }}
+	for i := range size {
+		if n == 0 || !keep(i) {
+			continue
+		}
+		if h.Len() < n {
+			heap.Push(h, i)
+		} else if better(i, h.Positions[0]) {
+			h.Positions[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+
+	out := make([]int, h.Len())
+	for k := len(out) - 1; k >= 0; k-- {
+		out[k] = heap.Pop(h).(int)
+	}
+	return out
+}
+
+// Heap is a container/heap of positions whose root is the first position in the order given
+// by Before.
+type Heap struct {
+	Positions []int
+	Before    func(a, b int) bool
+}
+
+func (h Heap) Len() int           { return len(h.Positions) }
+func (h Heap) Less(i, j int) bool { return h.Before(h.Positions[i], h.Positions[j]) }
+func (h Heap) Swap(i, j int)      { h.Positions[i], h.Positions[j] = h.Positions[j], h.Positions[i] }
+func (h *Heap) Push(x any)        { h.Positions = append(h.Positions, x.(int)) }
+func (h *Heap) Pop() any {
+	last := h.Positions[len(h.Positions)-1]
+	h.Positions = h.Positions[:len(h.Positions)-1]
+	return last
+}
@@ -0,0 +1,19 @@
+package topn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestPositions(t *testing.T) {
+	vals := []int{5, 9, 1, 9, 7}
+	even := func(i int) bool { return i%2 == 0 }
+	byValue := func(a, b int) int { return vals[a] - vals[b] }
+
+	assert.Equal(t, fmt.Sprint(Positions(2, len(vals), even, byValue)), "[4 0]")
+	all := func(int) bool { return true }
+	assert.Equal(t, fmt.Sprint(Positions(3, len(vals), all, byValue)), "[1 3 4]")
+	assert.Panic(t, func() { Positions(-1, len(vals), all, byValue) })
+}
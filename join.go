@@ -0,0 +1,640 @@
+package golumn
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/chriso345/golumn/internal/topn"
+	"github.com/chriso345/golumn/series"
+)
+
+// indexKey is a join key name that refers to the DataFrame index rather than a column.
+const indexKey = "__index__"
+
+// JoinHow selects which unmatched rows a join keeps.
+type JoinHow string
+
+const (
+	InnerJoin JoinHow = "inner" // only rows with a match on both sides
+	LeftJoin  JoinHow = "left"  // all left rows, matched where possible
+	RightJoin JoinHow = "right" // all right rows, matched where possible
+	OuterJoin JoinHow = "outer" // all rows from both sides
+)
+
+// MergeOptions defines the settings for DataFrame.Merge.
+type MergeOptions struct {
+	On      []string // key columns shared by both sides; shorthand for LeftOn == RightOn
+	LeftOn  []string
+	RightOn []string
+	How     JoinHow // defaults to InnerJoin
+
+	// Suffixes are appended to left and right non-key columns whose names collide.
+	// Defaults to {"_x", "_y"}.
+	Suffixes [2]string
+
+	// Validate checks key uniqueness before joining: "one_to_one" ("1:1"),
+	// "one_to_many" ("1:m"), "many_to_one" ("m:1") or "many_to_many" ("m:m").
+	Validate string
+
+	// Indicator adds a "_merge" column holding "left_only", "right_only" or "both".
+	Indicator bool
+
+	// Sort orders the result by the join keys (ascending, nulls last) instead of by row order.
+	Sort bool
+}
+
+// Merge joins df with other on one or more key columns. Key columns with the same name on
+// both sides are emitted once, taking the right value for right-only rows; values missing
+// from the unmatched side are null. Rows with a null key never match.
+//
+// Output rows are stable: left rows appear in their original order, each followed by its
+// matches in right order, and unmatched right rows come last in right order. Set Sort to
+// order by the keys instead.
+func (df DataFrame) Merge(other DataFrame, opts MergeOptions) DataFrame {
+	leftOn, rightOn := opts.LeftOn, opts.RightOn
+	if len(opts.On) > 0 {
+		if len(leftOn) > 0 || len(rightOn) > 0 {
+			panic("On cannot be combined with LeftOn or RightOn")
+		}
+		leftOn, rightOn = opts.On, opts.On
+	}
+	if len(leftOn) == 0 || len(leftOn) != len(rightOn) {
+		panic(fmt.Errorf("LeftOn and RightOn must name the same non-zero number of keys, got %v and %v", len(leftOn), len(rightOn)))
+	}
+
+	how := opts.How
+	if how == "" {
+		how = InnerJoin
+	}
+	switch how {
+	case InnerJoin, LeftJoin, RightJoin, OuterJoin:
+	default:
+		panic(fmt.Errorf("unknown join type %q", how))
+	}
+
+	suffixes := opts.Suffixes
+	if suffixes == [2]string{} {
+		suffixes = [2]string{"_x", "_y"}
+	}
+
+	lkeys := df.keyColumns(leftOn)
+	rkeys := other.keyColumns(rightOn)
+	if len(lkeys) != len(rkeys) {
+		panic(fmt.Errorf("cannot join %v key columns with %v; the indexes have different numbers of levels", len(lkeys), len(rkeys)))
+	}
+	rindex := newKeyIndex(rkeys, true)
+	if opts.Validate != "" {
+		validateMerge(opts.Validate, lkeys, rindex)
+	}
+
+	var lpos, rpos []int
+	matched := make([]bool, other.nrows)
+	for i := range df.nrows {
+		g := -1
+		if !anyNullKey(lkeys, i) {
+			g = rindex.find(lkeys, i)
+		}
+		if g == -1 {
+			if how == LeftJoin || how == OuterJoin {
+				lpos = append(lpos, i)
+				rpos = append(rpos, -1)
+			}
+			continue
+		}
+		for _, j := range rindex.groups[g] {
+			lpos = append(lpos, i)
+			rpos = append(rpos, j)
+			matched[j] = true
+		}
+	}
+	if how == RightJoin || how == OuterJoin {
+		for j := range other.nrows {
+			if !matched[j] {
+				lpos = append(lpos, -1)
+				rpos = append(rpos, j)
+			}
+		}
+	}
+
+	if opts.Sort {
+		keys := make([]series.Series, len(lkeys))
+		for k := range lkeys {
+			keys[k] = coalesceKey(lkeys[k], rkeys[k], lpos, rpos)
+		}
+		order := sortedPositions(keys, len(lpos))
+		lpos = reorder(lpos, order)
+		rpos = reorder(rpos, order)
+	}
+
+	cols := df.joinColumns(other, leftOn, rightOn, lpos, rpos, suffixes)
+	if opts.Indicator {
+		cols = append(cols, mergeIndicator(lpos, rpos))
+	}
+	return New(cols...)
+}
+
+// SemiJoin returns the rows of df that have at least one match in other on the given key
+// columns, keeping df's columns, index and row order. Rows with a null key never match.
+func (df DataFrame) SemiJoin(other DataFrame, on ...string) DataFrame {
+	return df.filterJoin(other, on, true)
+}
+
+// AntiJoin returns the rows of df that have no match in other on the given key columns,
+// keeping df's columns, index and row order. Rows with a null key never match and are kept.
+func (df DataFrame) AntiJoin(other DataFrame, on ...string) DataFrame {
+	return df.filterJoin(other, on, false)
+}
+
+// filterJoin keeps the rows of df whose match status in other equals keepMatched.
+func (df DataFrame) filterJoin(other DataFrame, on []string, keepMatched bool) DataFrame {
+	if len(on) == 0 {
+		panic("no join keys specified")
+	}
+
+	lkeys := df.keyColumns(on)
+	rkeys := other.keyColumns(on)
+	if len(lkeys) != len(rkeys) {
+		panic(fmt.Errorf("cannot join %v key columns with %v; the indexes have different numbers of levels", len(lkeys), len(rkeys)))
+	}
+	rindex := newKeyIndex(rkeys, true)
+
+	var keep []int
+	for i := range df.nrows {
+		matched := !anyNullKey(lkeys, i) && rindex.find(lkeys, i) != -1
+		if matched == keepMatched {
+			keep = append(keep, i)
+		}
+	}
+	return df.takeRows(keep)
+}
+
+// CrossJoin returns the cartesian product of df and other: every left row paired with every
+// right row, in left order then right order. Colliding right-hand column names get suffix "_y".
+func (df DataFrame) CrossJoin(other DataFrame) DataFrame {
+	lpos := make([]int, 0, df.nrows*other.nrows)
+	rpos := make([]int, 0, df.nrows*other.nrows)
+	for i := range df.nrows {
+		for j := range other.nrows {
+			lpos = append(lpos, i)
+			rpos = append(rpos, j)
+		}
+	}
+	return New(df.joinColumns(other, nil, nil, lpos, rpos, [2]string{"", "_y"})...)
+}
+
+// keyColumns resolves key names to series, mapping indexKey to every level of the DataFrame
+// index. A name that matches no column refers to the index level of that name, so the keys of
+// a PivotTable or GroupBy.Aggregate result made with AsIndex can still be used.
+func (df DataFrame) keyColumns(names []string) []series.Series {
+	cols := make([]series.Series, 0, len(names))
+	for _, name := range names {
+		if name == indexKey {
+			cols = append(cols, df.index.levels...)
+		} else if j := df.columnIndex(name); j >= 0 {
+			cols = append(cols, df.columns[j])
+		} else if k := slices.Index(df.index.Names(), name); k >= 0 {
+			cols = append(cols, df.index.levels[k])
+		} else {
+			panic(fmt.Errorf("column %v not found", name))
+		}
+	}
+	return cols
+}
+
+// joinColumns assembles the output columns of a join from paired row positions, where -1
+// marks a missing side. Left columns come first, then right columns; key columns sharing a
+// name are emitted once and other colliding names are suffixed. A shared key that the left side
+// holds as an index level rather than a column leads the output.
+func (df DataFrame) joinColumns(other DataFrame, leftOn, rightOn []string, lpos, rpos []int, suffixes [2]string) []series.Series {
+	// keys with the same column name on both sides collapse into a single output column
+	shared := make(map[string]int)
+	for k := range leftOn {
+		if leftOn[k] == rightOn[k] && leftOn[k] != indexKey {
+			shared[leftOn[k]] = k
+		}
+	}
+
+	leftNames := make(map[string]bool)
+	for _, s := range df.columns {
+		leftNames[s.Name] = true
+	}
+	rightNames := make(map[string]bool)
+	for _, s := range other.columns {
+		if _, ok := shared[s.Name]; !ok {
+			rightNames[s.Name] = true
+		}
+	}
+
+	// shared keys are resolved like the join keys, so either side may hold them in its index
+	sharedKey := func(name string) series.Series {
+		return coalesceKey(df.keyColumns([]string{name})[0], other.keyColumns([]string{name})[0], lpos, rpos)
+	}
+
+	cols := make([]series.Series, 0, df.ncols+other.ncols)
+	for k, name := range leftOn {
+		if j, ok := shared[name]; ok && j == k && df.columnIndex(name) < 0 {
+			cols = append(cols, sharedKey(name))
+		}
+	}
+	for _, s := range df.columns {
+		if _, ok := shared[s.Name]; ok {
+			cols = append(cols, sharedKey(s.Name))
+			continue
+		}
+		col := s.Take(lpos)
+		if rightNames[s.Name] {
+			col.Name += suffixes[0]
+		}
+		cols = append(cols, col)
+	}
+	for _, s := range other.columns {
+		if _, ok := shared[s.Name]; ok {
+			continue
+		}
+		col := s.Take(rpos)
+		if leftNames[s.Name] {
+			col.Name += suffixes[1]
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// coalesceKey builds a shared key column, taking left values where present and right values otherwise.
+func coalesceKey(left, right series.Series, lpos, rpos []int) series.Series {
+	vals := make([]any, len(lpos))
+	for r := range lpos {
+		if lpos[r] >= 0 {
+			vals[r] = left.Val(lpos[r])
+		} else if rpos[r] >= 0 {
+			vals[r] = right.Val(rpos[r])
+		}
+	}
+	return series.New(vals, series.CommonType(left.Type(), right.Type()), left.Name)
+}
+
+// reorder returns positions rearranged according to order.
+func reorder(positions, order []int) []int {
+	out := make([]int, len(order))
+	for i, o := range order {
+		out[i] = positions[o]
+	}
+	return out
+}
+
+// mergeIndicator builds the "_merge" column describing which side each joined row came from.
+func mergeIndicator(lpos, rpos []int) series.Series {
+	vals := make([]string, len(lpos))
+	for r := range lpos {
+		switch {
+		case lpos[r] >= 0 && rpos[r] >= 0:
+			vals[r] = "both"
+		case lpos[r] >= 0:
+			vals[r] = "left_only"
+		default:
+			vals[r] = "right_only"
+		}
+	}
+	return series.New(vals, series.String, "_merge")
+}
+
+// validateMerge panics if the join keys do not satisfy the requested relationship.
+func validateMerge(validate string, lkeys []series.Series, rindex *keyIndex) {
+	var leftUnique, rightUnique bool
+	switch validate {
+	case "one_to_one", "1:1":
+		leftUnique, rightUnique = true, true
+	case "one_to_many", "1:m":
+		leftUnique = true
+	case "many_to_one", "m:1":
+		rightUnique = true
+	case "many_to_many", "m:m":
+	default:
+		panic(fmt.Errorf("unknown merge validation %q", validate))
+	}
+
+	if leftUnique {
+		for _, rows := range newKeyIndex(lkeys, true).groups {
+			if len(rows) > 1 {
+				panic(fmt.Errorf("merge keys are not unique in left DataFrame: %v", validate))
+			}
+		}
+	}
+	if rightUnique {
+		for _, rows := range rindex.groups {
+			if len(rows) > 1 {
+				panic(fmt.Errorf("merge keys are not unique in right DataFrame: %v", validate))
+			}
+		}
+	}
+}
+
+// joinInternal joins on a single key per side, suffixing colliding right-hand columns with "_y".
+// onL/onR may be column names or indexKey to join on the DataFrame index. Row order follows
+// Merge: left order, then right-only rows in right order.
+func (df DataFrame) joinInternal(other DataFrame, onL, onR string, how JoinHow) DataFrame {
+	return df.Merge(other, MergeOptions{
+		LeftOn:   []string{onL},
+		RightOn:  []string{onR},
+		How:      how,
+		Suffixes: [2]string{"", "_y"},
+	})
+}
+
+// Join performs an inner join with another DataFrame on the specified column name.
+// If column names collide (other than the join column), suffix "_y" is added to the right-hand columns.
+func (df DataFrame) Join(other DataFrame, on string) DataFrame {
+	return df.joinInternal(other, on, on, InnerJoin)
+}
+
+// JoinLeft performs a left outer join on column 'on'.
+func (df DataFrame) JoinLeft(other DataFrame, on string) DataFrame {
+	return df.joinInternal(other, on, on, LeftJoin)
+}
+
+// JoinRight performs a right outer join on column 'on'.
+func (df DataFrame) JoinRight(other DataFrame, on string) DataFrame {
+	return df.joinInternal(other, on, on, RightJoin)
+}
+
+// JoinFull performs a full outer join on column 'on'.
+func (df DataFrame) JoinFull(other DataFrame, on string) DataFrame {
+	return df.joinInternal(other, on, on, OuterJoin)
+}
+
+// JoinIndex performs an inner join on the DataFrame indices.
+func (df DataFrame) JoinIndex(other DataFrame) DataFrame {
+	return df.joinInternal(other, indexKey, indexKey, InnerJoin)
+}
+
+// JoinLeftIndex performs a left outer join on the DataFrame indices.
+func (df DataFrame) JoinLeftIndex(other DataFrame) DataFrame {
+	return df.joinInternal(other, indexKey, indexKey, LeftJoin)
+}
+
+// JoinRightIndex performs a right outer join on the DataFrame indices.
+func (df DataFrame) JoinRightIndex(other DataFrame) DataFrame {
+	return df.joinInternal(other, indexKey, indexKey, RightJoin)
+}
+
+// JoinFullIndex performs a full outer join on the DataFrame indices.
+func (df DataFrame) JoinFullIndex(other DataFrame) DataFrame {
+	return df.joinInternal(other, indexKey, indexKey, OuterJoin)
+}
+
+// AsOfDirection selects which neighbouring right key an as-of join matches.
+type AsOfDirection int
+
+const (
+	// AsOfBackward matches the last right row whose key is less than or equal to the left key.
+	AsOfBackward AsOfDirection = iota
+	// AsOfForward matches the first right row whose key is greater than or equal to the left key.
+	AsOfForward
+	// AsOfNearest matches the closest right key in either direction, preferring backward on ties.
+	AsOfNearest
+)
+
+// AsOfOptions defines the settings for DataFrame.JoinAsOf.
+type AsOfOptions struct {
+	On        string   // ordered key column present in both DataFrames
+	By        []string // columns that must match exactly before the nearest key is searched
+	Direction AsOfDirection
+
+	// Tolerance is the largest allowed distance between matched keys, as an int or float64, or
+	// as a time.Duration for datetime keys. nil means no limit.
+	Tolerance any
+}
+
+// JoinAsOf performs a left join that matches each row to the nearest key in other rather than
+// an equal one, e.g. each trade to the latest preceding quote. Both DataFrames must be sorted
+// ascending by On; rows with a null key are ignored. The On and By columns appear once, and
+// unmatched rows get null right-hand values.
+func (df DataFrame) JoinAsOf(other DataFrame, opts AsOfOptions) DataFrame {
+	if opts.On == "" {
+		panic("no as-of key specified")
+	}
+	lkey := *df.Column(opts.On)
+	rkey := *other.Column(opts.On)
+	checkAsOfSorted(lkey, "left")
+	checkAsOfSorted(rkey, "right")
+
+	tolerance := -1.0
+	if opts.Tolerance != nil {
+		t, ok := toFloat(opts.Tolerance)
+		if d, isDuration := opts.Tolerance.(time.Duration); isDuration {
+			t, ok = float64(d), true
+		}
+		if !ok || t < 0 {
+			panic(fmt.Errorf("invalid as-of tolerance %v", opts.Tolerance))
+		}
+		tolerance = t
+	}
+
+	// right rows with a usable key, grouped by the exact-match columns
+	var groups [][]int
+	var lby []series.Series
+	var rindex *keyIndex
+	if len(opts.By) > 0 {
+		lby = df.keyColumns(opts.By)
+		rindex = newKeyIndex(other.keyColumns(opts.By), true)
+		groups = make([][]int, len(rindex.groups))
+		for g, rows := range rindex.groups {
+			for _, j := range rows {
+				if rkey.IsValid(j) {
+					groups[g] = append(groups[g], j)
+				}
+			}
+		}
+	} else {
+		var rows []int
+		for j := range other.nrows {
+			if rkey.IsValid(j) {
+				rows = append(rows, j)
+			}
+		}
+		groups = [][]int{rows}
+	}
+
+	// le[g] counts right keys <= the current left key and lt[g] those strictly below it; both
+	// only move forward because the left keys are sorted too
+	le := make([]int, len(groups))
+	lt := make([]int, len(groups))
+
+	lpos := make([]int, df.nrows)
+	rpos := make([]int, df.nrows)
+	for i := range df.nrows {
+		lpos[i] = i
+		rpos[i] = -1
+		if lkey.IsNull(i) {
+			continue
+		}
+		g := 0
+		if rindex != nil {
+			if anyNullKey(lby, i) {
+				continue
+			}
+			if g = rindex.find(lby, i); g == -1 {
+				continue
+			}
+		}
+
+		x := orderedKey(lkey, i)
+		rows := groups[g]
+		for le[g] < len(rows) && compareValues(orderedKey(rkey, rows[le[g]]), x) <= 0 {
+			le[g]++
+		}
+		for lt[g] < len(rows) && compareValues(orderedKey(rkey, rows[lt[g]]), x) < 0 {
+			lt[g]++
+		}
+
+		best, dist := -1, 0.0
+		if opts.Direction != AsOfForward && le[g] > 0 {
+			best = rows[le[g]-1]
+			dist = keyDistance(orderedKey(rkey, best), x)
+		}
+		if opts.Direction != AsOfBackward && lt[g] < len(rows) {
+			j := rows[lt[g]]
+			if d := keyDistance(x, orderedKey(rkey, j)); best == -1 || d < dist {
+				best, dist = j, d
+			}
+		}
+		if best != -1 && (tolerance < 0 || dist <= tolerance) {
+			rpos[i] = best
+		}
+	}
+
+	on := append([]string{opts.On}, opts.By...)
+	return New(df.joinColumns(other, on, on, lpos, rpos, [2]string{"", "_y"})...)
+}
+
+// orderedKey returns the key at row i for comparison with compareValues. Datetimes stay
+// time.Time so that keys a nanosecond apart still differ; numbers become float64. It is shared
+// by the as-of and range joins.
+func orderedKey(s series.Series, i int) any {
+	v := s.Val(i)
+	if _, ok := v.(time.Time); ok {
+		return v
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		panic(fmt.Errorf("join key %v has unsupported type %v", s.Name, s.Type()))
+	}
+	return f
+}
+
+// keyDistance returns b - a for two keys from orderedKey, in nanoseconds for datetimes.
+func keyDistance(a, b any) float64 {
+	ta, aTime := a.(time.Time)
+	tb, bTime := b.(time.Time)
+	switch {
+	case aTime && bTime:
+		return float64(tb.Sub(ta))
+	case aTime || bTime:
+		panic(fmt.Errorf("cannot compare join keys %v and %v", a, b))
+	}
+	return b.(float64) - a.(float64)
+}
+
+// checkAsOfSorted panics if the non-null keys of s are not in ascending order.
+func checkAsOfSorted(s series.Series, side string) {
+	var prev any
+	for i := range s.Len() {
+		if s.IsNull(i) {
+			continue
+		}
+		v := orderedKey(s, i)
+		if prev != nil && compareValues(v, prev) < 0 {
+			panic(fmt.Errorf("as-of key %v must be sorted ascending in the %v DataFrame", s.Name, side))
+		}
+		prev = v
+	}
+}
+
+// Inclusive selects which interval bounds a range join treats as part of the interval.
+type Inclusive int
+
+const (
+	InclusiveBoth    Inclusive = iota // start <= x <= end
+	InclusiveLeft                     // start <= x < end
+	InclusiveRight                    // start < x <= end
+	InclusiveNeither                  // start < x < end
+)
+
+// JoinRange performs an inner join matching each row of df to every row of other whose
+// interval [rightStart, rightEnd] contains the value of leftCol, with bounds included as set by
+// inclusive. Rows are emitted in left order, then right order, and use the same column layout
+// as Join. Rows with null values or bounds never match.
+func (df DataFrame) JoinRange(other DataFrame, leftCol, rightStart, rightEnd string, inclusive Inclusive) DataFrame {
+	values := *df.Column(leftCol)
+	starts := *other.Column(rightStart)
+	ends := *other.Column(rightEnd)
+
+	var points []int
+	for i := range df.nrows {
+		if values.IsValid(i) {
+			points = append(points, i)
+		}
+	}
+	slices.SortStableFunc(points, func(a, b int) int {
+		return compareValues(orderedKey(values, a), orderedKey(values, b))
+	})
+
+	var intervals []int
+	for j := range other.nrows {
+		if starts.IsValid(j) && ends.IsValid(j) {
+			intervals = append(intervals, j)
+		}
+	}
+	slices.SortStableFunc(intervals, func(a, b int) int {
+		return compareValues(orderedKey(starts, a), orderedKey(starts, b))
+	})
+
+	startOK := func(start, x any) bool {
+		if inclusive == InclusiveBoth || inclusive == InclusiveLeft {
+			return compareValues(start, x) <= 0
+		}
+		return compareValues(start, x) < 0
+	}
+	endOK := func(end, x any) bool {
+		if inclusive == InclusiveBoth || inclusive == InclusiveRight {
+			return compareValues(x, end) <= 0
+		}
+		return compareValues(x, end) < 0
+	}
+
+	// sweep the points in ascending order, keeping the intervals that have started in a
+	// min-heap by end so that expired intervals can be dropped from the top
+	active := &topn.Heap{Before: func(a, b int) bool {
+		return compareValues(orderedKey(ends, a), orderedKey(ends, b)) < 0
+	}}
+	var pairs [][2]int
+	next := 0
+	for _, i := range points {
+		x := orderedKey(values, i)
+		for next < len(intervals) && startOK(orderedKey(starts, intervals[next]), x) {
+			heap.Push(active, intervals[next])
+			next++
+		}
+		for active.Len() > 0 && !endOK(orderedKey(ends, active.Positions[0]), x) {
+			heap.Pop(active)
+		}
+		for _, j := range active.Positions {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+
+	slices.SortFunc(pairs, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	lpos := make([]int, len(pairs))
+	rpos := make([]int, len(pairs))
+	for k, p := range pairs {
+		lpos[k], rpos[k] = p[0], p[1]
+	}
+	return New(df.joinColumns(other, nil, nil, lpos, rpos, [2]string{"", "_y"})...)
+}
@@ -0,0 +1,376 @@
+package golumn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestMerge_MultiKeySuffixesIndicator(t *testing.T) {
+	left := New(
+		series.New([]string{"a", "a", "b"}, series.String, "k1"),
+		series.New([]int{1, 2, 1}, series.Int, "k2"),
+		series.New([]int{10, 20, 30}, series.Int, "val"),
+	)
+	right := New(
+		series.New([]string{"a", "b", "c"}, series.String, "k1"),
+		series.New([]int{2, 1, 1}, series.Int, "k2"),
+		series.New([]float64{0.5, 1.5, 2.5}, series.Float, "val"),
+	)
+
+	m := left.Merge(right, MergeOptions{
+		On:        []string{"k1", "k2"},
+		How:       OuterJoin,
+		Suffixes:  [2]string{"_l", "_r"},
+		Indicator: true,
+	})
+
+	r, _ := m.Shape()
+	assert.Equal(t, r, 4)
+	names := m.Names()
+	assert.Equal(t, len(names), 5)
+	assert.Equal(t, names[2], "val_l")
+	assert.Equal(t, names[3], "val_r")
+	assert.Equal(t, names[4], "_merge")
+
+	// left row (a, 1) has no match
+	assert.Equal(t, m.Column("val_l").Val(0), 10)
+	assert.Equal(t, m.Column("val_r").IsNull(0), true)
+	assert.Equal(t, m.Column("_merge").Val(0), "left_only")
+	assert.Equal(t, m.Column("val_r").Val(1), 0.5)
+	assert.Equal(t, m.Column("_merge").Val(1), "both")
+	// right-only row (c, 1) fills the shared key columns from the right
+	assert.Equal(t, m.Column("k1").Val(3), "c")
+	assert.Equal(t, m.Column("k2").Val(3), 1)
+	assert.Equal(t, m.Column("val_l").IsNull(3), true)
+	assert.Equal(t, m.Column("_merge").Val(3), "right_only")
+}
+
+func TestMerge_DifferentKeyNames(t *testing.T) {
+	left := New(
+		series.New([]int{1, 2}, series.Int, "id"),
+		series.New([]string{"A", "B"}, series.String, "name"),
+	)
+	right := New(
+		series.New([]int{2, 3}, series.Int, "user_id"),
+		series.New([]string{"X", "Y"}, series.String, "name"),
+	)
+
+	m := left.Merge(right, MergeOptions{LeftOn: []string{"id"}, RightOn: []string{"user_id"}, How: LeftJoin})
+	assert.Equal(t, len(m.Names()), 4)
+	assert.Equal(t, m.Names()[1], "name_x")
+	assert.Equal(t, m.Names()[2], "user_id")
+	assert.Equal(t, m.Names()[3], "name_y")
+	assert.Equal(t, m.Column("user_id").IsNull(0), true)
+	assert.Equal(t, m.Column("name_y").Val(1), "X")
+}
+
+func TestMerge_SharedKeyInIndex(t *testing.T) {
+	cols := New(
+		series.New([]int{1, 2}, series.Int, "k"),
+		series.New([]string{"A", "B"}, series.String, "name"),
+	)
+	indexed := New(series.New([]string{"X", "Y"}, series.String, "code")).
+		SetIndex(series.New([]int{2, 3}, series.Int, "k"))
+
+	right := cols.Merge(indexed, MergeOptions{On: []string{"k"}, How: OuterJoin})
+	assert.Equal(t, fmt.Sprint(right.Names()), "[k name code]")
+	assert.Equal(t, right.Column("k").Val(2), 3)
+	assert.Equal(t, right.Column("code").Val(1), "X")
+
+	left := indexed.Merge(cols, MergeOptions{On: []string{"k"}, How: OuterJoin})
+	assert.Equal(t, fmt.Sprint(left.Names()), "[k code name]")
+	assert.Equal(t, left.Column("k").Val(2), 1)
+	assert.Equal(t, left.Column("name").Val(0), "B")
+}
+
+func TestMerge_NullKeysDoNotMatch(t *testing.T) {
+	lk := series.New([]int{1, 2}, series.Int, "id")
+	lk.Elem(1).Set(nil)
+	left := New(lk, series.New([]int{10, 20}, series.Int, "a"))
+	rk := series.New([]int{1, 2}, series.Int, "id")
+	rk.Elem(1).Set(nil)
+	right := New(rk, series.New([]int{100, 200}, series.Int, "b"))
+
+	m := left.Merge(right, MergeOptions{On: []string{"id"}})
+	r, _ := m.Shape()
+	assert.Equal(t, r, 1)
+	assert.Equal(t, m.Column("b").Val(0), 100)
+}
+
+func TestMerge_Validate(t *testing.T) {
+	left := New(series.New([]int{1, 1}, series.Int, "id"))
+	right := New(series.New([]int{1}, series.Int, "id"))
+
+	m := left.Merge(right, MergeOptions{On: []string{"id"}, Validate: "many_to_one"})
+	r, _ := m.Shape()
+	assert.Equal(t, r, 2)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic for duplicate left keys with one_to_one validation")
+		}
+	}()
+	_ = left.Merge(right, MergeOptions{On: []string{"id"}, Validate: "one_to_one"})
+}
+
+func TestJoin_StableOrder(t *testing.T) {
+	left := New(
+		series.New([]int{5, 3, 9, 1, 3, 7}, series.Int, "id"),
+		series.New([]string{"a", "b", "c", "d", "e", "f"}, series.String, "name"),
+	)
+	right := New(
+		series.New([]int{3, 8, 1, 3, 2}, series.Int, "id"),
+		series.New([]int{30, 80, 10, 31, 20}, series.Int, "val"),
+	)
+
+	joins := map[string]func() DataFrame{
+		"inner": func() DataFrame { return left.Join(right, "id") },
+		"left":  func() DataFrame { return left.JoinLeft(right, "id") },
+		"right": func() DataFrame { return left.JoinRight(right, "id") },
+		"full":  func() DataFrame { return left.JoinFull(right, "id") },
+	}
+	for name, join := range joins {
+		first := join().String()
+		for range 50 {
+			if got := join().String(); got != first {
+				t.Fatalf("%s join output changed between runs:\n%s\nvs\n%s", name, first, got)
+			}
+		}
+	}
+
+	// left order, matches in right order, then right-only rows in right order
+	full := left.JoinFull(right, "id")
+	ids := full.Column("id")
+	vals := full.Column("val")
+	expectedIDs := []any{5, 3, 3, 9, 1, 3, 3, 7, 8, 2}
+	expectedVals := []any{nil, 30, 31, nil, 10, 30, 31, nil, 80, 20}
+	assert.Equal(t, ids.Len(), len(expectedIDs))
+	for i := range expectedIDs {
+		assert.Equal(t, ids.Val(i), expectedIDs[i])
+		assert.Equal(t, vals.Val(i), expectedVals[i])
+	}
+}
+
+func TestMerge_Sort(t *testing.T) {
+	left := New(
+		series.New([]string{"b", "a", "b"}, series.String, "k"),
+		series.New([]int{2, 1, 1}, series.Int, "n"),
+	)
+	right := New(
+		series.New([]string{"c", "a", "b"}, series.String, "k"),
+		series.New([]int{1, 1, 1}, series.Int, "n"),
+	)
+
+	m := left.Merge(right, MergeOptions{On: []string{"k", "n"}, How: OuterJoin, Sort: true})
+	keys := m.Column("k")
+	ns := m.Column("n")
+	expectedK := []any{"a", "b", "b", "c"}
+	expectedN := []any{1, 1, 2, 1}
+	for i := range expectedK {
+		assert.Equal(t, keys.Val(i), expectedK[i])
+		assert.Equal(t, ns.Val(i), expectedN[i])
+	}
+}
+
+func TestSemiAntiJoin(t *testing.T) {
+	lk := series.New([]int{1, 2, 3, 0}, series.Int, "id")
+	lk.Elem(3).Set(nil)
+	left := New(
+		lk,
+		series.New([]string{"a", "b", "c", "d"}, series.String, "tag"),
+		series.New([]string{"x", "y", "x", "y"}, series.String, "grp"),
+	)
+	right := New(
+		series.New([]int{2, 3, 3}, series.Int, "id"),
+		series.New([]string{"y", "y", "y"}, series.String, "grp"),
+	)
+
+	semi := left.SemiJoin(right, "id")
+	r, c := semi.Shape()
+	assert.Equal(t, r, 2)
+	assert.Equal(t, c, 3)
+	assert.Equal(t, semi.Column("tag").Val(0), "b")
+	assert.Equal(t, semi.Column("tag").Val(1), "c")
+	assert.Equal(t, semi.Index().Val(0), 1)
+
+	semi2 := left.SemiJoin(right, "id", "grp")
+	r, _ = semi2.Shape()
+	assert.Equal(t, r, 1)
+	assert.Equal(t, semi2.Column("tag").Val(0), "b")
+
+	anti := left.AntiJoin(right, "id")
+	r, _ = anti.Shape()
+	assert.Equal(t, r, 2)
+	assert.Equal(t, anti.Column("tag").Val(0), "a")
+	assert.Equal(t, anti.Column("tag").Val(1), "d")
+	assert.Equal(t, anti.Index().Val(1), 3)
+}
+
+func TestCrossJoin(t *testing.T) {
+	left := New(series.New([]string{"lo", "hi"}, series.String, "scenario"))
+	right := New(
+		series.New([]int{2025, 2026, 2027}, series.Int, "year"),
+		series.New([]string{"a", "b", "c"}, series.String, "scenario"),
+	)
+
+	cj := left.CrossJoin(right)
+	r, c := cj.Shape()
+	assert.Equal(t, r, 6)
+	assert.Equal(t, c, 3)
+	assert.Equal(t, cj.Names()[2], "scenario_y")
+	assert.Equal(t, cj.At(0, 0), "lo")
+	assert.Equal(t, cj.At(2, 1), 2027)
+	assert.Equal(t, cj.At(3, 0), "hi")
+	assert.Equal(t, cj.At(3, 1), 2025)
+}
+
+func TestJoinAsOf_Directions(t *testing.T) {
+	trades := New(
+		series.New([]int{1, 5, 10}, series.Int, "time"),
+		series.New([]float64{100, 101, 102}, series.Float, "price"),
+	)
+	quotes := New(
+		series.New([]float64{2, 4, 9}, series.Float, "time"),
+		series.New([]string{"q2", "q4", "q9"}, series.String, "quote"),
+	)
+
+	back := trades.JoinAsOf(quotes, AsOfOptions{On: "time"})
+	assert.Equal(t, len(back.Names()), 3)
+	assert.Equal(t, back.Column("quote").IsNull(0), true)
+	assert.Equal(t, back.Column("quote").Val(1), "q4")
+	assert.Equal(t, back.Column("quote").Val(2), "q9")
+
+	fwd := trades.JoinAsOf(quotes, AsOfOptions{On: "time", Direction: AsOfForward})
+	assert.Equal(t, fwd.Column("quote").Val(0), "q2")
+	assert.Equal(t, fwd.Column("quote").Val(1), "q9")
+	assert.Equal(t, fwd.Column("quote").IsNull(2), true)
+
+	near := trades.JoinAsOf(quotes, AsOfOptions{On: "time", Direction: AsOfNearest, Tolerance: 1})
+	assert.Equal(t, near.Column("quote").Val(0), "q2")
+	assert.Equal(t, near.Column("quote").Val(1), "q4")
+	assert.Equal(t, near.Column("quote").Val(2), "q9")
+
+	tight := trades.JoinAsOf(quotes, AsOfOptions{On: "time", Tolerance: 0.5})
+	assert.Equal(t, tight.Column("quote").CountNulls(), 3)
+}
+
+func TestJoinAsOf_By(t *testing.T) {
+	trades := New(
+		series.New([]int{3, 3, 7}, series.Int, "time"),
+		series.New([]string{"A", "B", "A"}, series.String, "sym"),
+	)
+	quotes := New(
+		series.New([]int{1, 2, 5, 6}, series.Int, "time"),
+		series.New([]string{"A", "B", "B", "A"}, series.String, "sym"),
+		series.New([]float64{1.0, 2.0, 5.0, 6.0}, series.Float, "bid"),
+	)
+
+	j := trades.JoinAsOf(quotes, AsOfOptions{On: "time", By: []string{"sym"}})
+	assert.Equal(t, len(j.Names()), 3)
+	assert.Equal(t, j.Column("bid").Val(0), 1.0)
+	assert.Equal(t, j.Column("bid").Val(1), 2.0)
+	assert.Equal(t, j.Column("bid").Val(2), 6.0)
+}
+
+func TestJoinAsOf_DatetimeKeysKeepNanoseconds(t *testing.T) {
+	base := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	trades := New(series.New([]time.Time{base.Add(100)}, series.Datetime, "ts"))
+	quotes := New(
+		series.New([]time.Time{base.Add(50), base.Add(101)}, series.Datetime, "ts"),
+		series.New([]string{"q50", "q101"}, series.String, "quote"),
+	)
+
+	back := trades.JoinAsOf(quotes, AsOfOptions{On: "ts"})
+	assert.Equal(t, back.Column("quote").Val(0), "q50")
+
+	near := trades.JoinAsOf(quotes, AsOfOptions{On: "ts", Direction: AsOfNearest, Tolerance: time.Duration(1)})
+	assert.Equal(t, near.Column("quote").Val(0), "q101")
+
+	ranges := New(
+		series.New([]time.Time{base.Add(101)}, series.Datetime, "from"),
+		series.New([]time.Time{base.Add(200)}, series.Datetime, "to"),
+	)
+	r, _ := trades.JoinRange(ranges, "ts", "from", "to", InclusiveBoth).Shape()
+	assert.Equal(t, r, 0)
+}
+
+func TestJoinAsOf_UnsortedPanics(t *testing.T) {
+	left := New(series.New([]int{3, 1}, series.Int, "time"))
+	right := New(series.New([]int{1, 2}, series.Int, "time"))
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic for unsorted as-of keys")
+		}
+	}()
+	_ = left.JoinAsOf(right, AsOfOptions{On: "time"})
+}
+
+func TestJoinRange(t *testing.T) {
+	events := New(
+		series.New([]int{15, 5, 10, 30}, series.Int, "ts"),
+		series.New([]string{"e1", "e2", "e3", "e4"}, series.String, "name"),
+	)
+	sessions := New(
+		series.New([]int{10, 0, 12}, series.Int, "start"),
+		series.New([]int{20, 10, 15}, series.Int, "end"),
+		series.New([]string{"s1", "s2", "s3"}, series.String, "name"),
+	)
+
+	j := events.JoinRange(sessions, "ts", "start", "end", InclusiveBoth)
+	assert.Equal(t, len(j.Names()), 5)
+	assert.Equal(t, j.Names()[4], "name_y")
+
+	expected := [][2]any{{"e1", "s1"}, {"e1", "s3"}, {"e2", "s2"}, {"e3", "s1"}, {"e3", "s2"}}
+	r, _ := j.Shape()
+	assert.Equal(t, r, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, j.Column("name").Val(i), e[0])
+		assert.Equal(t, j.Column("name_y").Val(i), e[1])
+	}
+
+	left := events.JoinRange(sessions, "ts", "start", "end", InclusiveLeft)
+	r, _ = left.Shape()
+	assert.Equal(t, r, 3) // e1-s1, e2-s2, e3-s1; 15 is not < 15 and 10 is not < 10
+
+	neither := events.JoinRange(sessions, "ts", "start", "end", InclusiveNeither)
+	r, _ = neither.Shape()
+	assert.Equal(t, r, 2) // e1-s1, e2-s2
+}
+
+func TestJoinRange_MatchesNestedLoop(t *testing.T) {
+	vals := []float64{3, 7.5, 1, 9, 4, 4, 12, 0}
+	starts := []float64{0, 2, 4, 8, 3.5, 11}
+	ends := []float64{3, 5, 4, 12, 9, 11}
+	left := New(series.New(vals, series.Float, "x"))
+	right := New(
+		series.New(starts, series.Float, "lo"),
+		series.New(ends, series.Float, "hi"),
+	)
+
+	for _, inc := range []Inclusive{InclusiveBoth, InclusiveLeft, InclusiveRight, InclusiveNeither} {
+		var want [][2]float64
+		for _, x := range vals {
+			for k := range starts {
+				lo := starts[k] < x || (starts[k] == x && (inc == InclusiveBoth || inc == InclusiveLeft))
+				hi := x < ends[k] || (x == ends[k] && (inc == InclusiveBoth || inc == InclusiveRight))
+				if lo && hi {
+					want = append(want, [2]float64{x, starts[k]})
+				}
+			}
+		}
+
+		j := left.JoinRange(right, "x", "lo", "hi", inc)
+		r, _ := j.Shape()
+		assert.Equal(t, r, len(want))
+		for i, w := range want {
+			assert.Equal(t, j.Column("x").Val(i), any(w[0]))
+			assert.Equal(t, j.Column("lo").Val(i), any(w[1]))
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package golumn
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// MultiIndex is a hierarchical row index. Each row is labelled by a tuple holding one value per
+// level, from the outermost level to the innermost. An ordinary index is a MultiIndex with a
+// single level.
+type MultiIndex struct {
+	levels []series.Series
+}
+
+// NewMultiIndex creates a MultiIndex from copies of the given levels, outermost first. All
+// levels must have the same length.
+func NewMultiIndex(levels ...series.Series) MultiIndex {
+	if len(levels) == 0 {
+		panic("a MultiIndex needs at least one level")
+	}
+	m := MultiIndex{levels: make([]series.Series, len(levels))}
+	for k, l := range levels {
+		if l.Len() != levels[0].Len() {
+			panic(fmt.Errorf("level %v has length %v, expected %v", k, l.Len(), levels[0].Len()))
+		}
+		m.levels[k] = l.Copy()
+	}
+	return m
+}
+
+// newIndex wraps levels in a MultiIndex without copying them.
+func newIndex(levels ...series.Series) MultiIndex {
+	return MultiIndex{levels: levels}
+}
+
+// rangeIndex returns the default index of n rows, labelled 0 to n-1.
+func rangeIndex(n int) MultiIndex {
+	return newIndex(series.New(positionRange(0, n), series.Int, "Index"))
+}
+
+// NLevels returns the number of levels.
+func (m MultiIndex) NLevels() int {
+	return len(m.levels)
+}
+
+// Len returns the number of rows labelled by the index.
+func (m MultiIndex) Len() int {
+	if len(m.levels) == 0 {
+		return 0
+	}
+	return m.levels[0].Len()
+}
+
+// Level returns a copy of level k of the index, where level 0 is the outermost.
+func (m MultiIndex) Level(k int) series.Series {
+	m.checkLevel(k)
+	return m.levels[k].Copy()
+}
+
+// Names returns the names of the levels, outermost first.
+func (m MultiIndex) Names() []string {
+	names := make([]string, len(m.levels))
+	for k, l := range m.levels {
+		names[k] = l.Name
+	}
+	return names
+}
+
+// Label returns the label tuple of row i.
+func (m MultiIndex) Label(i int) []any {
+	label := make([]any, len(m.levels))
+	for k, l := range m.levels {
+		label[k] = l.Val(i)
+	}
+	return label
+}
+
+// checkLevel panics if k is not a level of the index.
+func (m MultiIndex) checkLevel(k int) {
+	if k < 0 || k >= len(m.levels) {
+		panic(fmt.Errorf("level %v out of range for an index with %v levels", k, len(m.levels)))
+	}
+}
+
+// take returns the index of the rows at the given positions; negative positions give nulls.
+func (m MultiIndex) take(positions []int) MultiIndex {
+	levels := make([]series.Series, len(m.levels))
+	for k, l := range m.levels {
+		levels[k] = l.Take(positions)
+	}
+	return newIndex(levels...)
+}
+
+// slice returns the index of rows a to b.
+func (m MultiIndex) slice(a, b int) MultiIndex {
+	levels := make([]series.Series, len(m.levels))
+	for k, l := range m.levels {
+		levels[k] = l.Slice(a, b)
+	}
+	return newIndex(levels...)
+}
+
+// copy returns a deep copy of the index.
+func (m MultiIndex) copy() MultiIndex {
+	levels := make([]series.Series, len(m.levels))
+	for k, l := range m.levels {
+		levels[k] = l.Copy()
+	}
+	return newIndex(levels...)
+}
+
+// swap exchanges the labels of rows i and j in place.
+func (m MultiIndex) swap(i, j int) {
+	for _, l := range m.levels {
+		l.Swap(i, j)
+	}
+}
+
+// without returns the index with level k removed.
+func (m MultiIndex) without(k int) MultiIndex {
+	return newIndex(slices.Delete(slices.Clone(m.levels), k, k+1)...)
+}
+
+// labelName returns the label of row i as a single name, joining the levels with "_". Null
+// labels print as the empty string.
+func (m MultiIndex) labelName(i int) string {
+	parts := make([]string, len(m.levels))
+	for k, l := range m.levels {
+		if l.IsValid(i) {
+			parts[k] = fmt.Sprint(l.Val(i))
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// printedLabels returns the labels of the given rows printed in that order, one padded string
+// per row. Labels of an outer level are hidden where they and all levels outside them repeat
+// the row printed above, as GroupBy.String does for group keys.
+func (m MultiIndex) printedLabels(rows []int) []string {
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(m.levels))
+	for r, i := range rows {
+		cells[r] = make([]string, len(m.levels))
+		same := r > 0
+		for k, l := range m.levels {
+			same = same && valuesEqual(l.Val(i), l.Val(rows[r-1]))
+			if same && k < len(m.levels)-1 {
+				continue
+			}
+			cells[r][k] = l.FormatVal(i)
+			widths[k] = max(widths[k], len(cells[r][k]))
+		}
+	}
+
+	out := make([]string, len(rows))
+	for r, row := range cells {
+		for k, c := range row {
+			row[k] = padLeft(c, widths[k])
+		}
+		out[r] = strings.Join(row, "  ")
+	}
+	return out
+}
+
+// MultiIndex returns a copy of the index of the DataFrame with all of its levels. Changing it
+// leaves the DataFrame untouched; use SetIndex to replace the index.
+func (df DataFrame) MultiIndex() MultiIndex {
+	return df.index.copy()
+}
+
+// XS returns the cross-section of rows whose label at the given index level equals key, in
+// their original order. The level is dropped from the index of the result unless it is the
+// only one. It panics if no row matches.
+func (df DataFrame) XS(key any, level int) DataFrame {
+	df.index.checkLevel(level)
+	rows := df.levelRows(level, key)
+	if len(rows) == 0 {
+		panic(fmt.Errorf("key %v not found in index level %v", key, level))
+	}
+
+	out := df.takeRows(rows)
+	if out.index.NLevels() > 1 {
+		out.index = out.index.without(level)
+	}
+	return out
+}
+
+// levelRows returns the positions of the rows whose label at the given level equals key.
+func (df DataFrame) levelRows(level int, key any) []int {
+	var rows []int
+	l := df.index.levels[level]
+	for i := range df.nrows {
+		if valuesEqual(l.Val(i), key) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// Droplevel returns a copy of the DataFrame with the given level removed from its index. It
+// panics if the index has a single level.
+func (df DataFrame) Droplevel(level int) DataFrame {
+	df.index.checkLevel(level)
+	if df.index.NLevels() == 1 {
+		panic("cannot drop the only level of the index")
+	}
+	out := df.Copy()
+	out.index = out.index.without(level)
+	return out
+}
+
+// SwapLevel returns a copy of the DataFrame with index levels i and j exchanged.
+func (df DataFrame) SwapLevel(i, j int) DataFrame {
+	df.index.checkLevel(i)
+	df.index.checkLevel(j)
+	out := df.Copy()
+	out.index.levels[i], out.index.levels[j] = out.index.levels[j], out.index.levels[i]
+	return out
+}
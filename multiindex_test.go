@@ -0,0 +1,148 @@
+package golumn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestMultiIndex_New(t *testing.T) {
+	m := NewMultiIndex(
+		series.New([]string{"a", "b"}, series.String, "K1"),
+		series.New([]int{1, 2}, series.Int, "K2"),
+	)
+	assert.Equal(t, m.NLevels(), 2)
+	assert.Equal(t, m.Len(), 2)
+	assert.Equal(t, fmt.Sprint(m.Names()), "[K1 K2]")
+	assert.Equal(t, fmt.Sprint(m.Label(1)), "[b 2]")
+	assert.Equal(t, m.Level(1).Val(0), 1)
+
+	assert.Panic(t, func() { NewMultiIndex() })
+	assert.Panic(t, func() {
+		NewMultiIndex(series.New([]int{1}, series.Int, "A"), series.New([]int{1, 2}, series.Int, "B"))
+	})
+	assert.Panic(t, func() { m.Level(2) })
+
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+	// Index reads only the outermost level
+	assert.Equal(t, df.Index().Name, "Outer")
+	assert.Equal(t, df.Index().Val(2), "b")
+}
+
+func TestMultiIndex_String(t *testing.T) {
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+	expected := "      Value\na  1      1\n   2      2\nb  1      3\n   2      4"
+	assert.Equal(t, df.String(), expected)
+
+	// the outer label is shown again whenever it changes
+	df.Swap(1, 2)
+	expected = "      Value\na  1      1\nb  1      3\na  2      2\nb  2      4"
+	assert.Equal(t, df.String(), expected)
+}
+
+func TestMultiIndex_XS(t *testing.T) {
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+
+	b := df.XS("b", 0)
+	assert.Equal(t, b.nrows, 2)
+	assert.Equal(t, b.MultiIndex().NLevels(), 1)
+	assert.Equal(t, b.Index().Name, "Inner")
+	assert.Equal(t, b.At(0, 0), 3)
+
+	second := df.XS(2, 1)
+	assert.Equal(t, second.Index().Name, "Outer")
+	assert.Equal(t, second.Column("Value").Val(1), 4)
+
+	assert.Panic(t, func() { df.XS("c", 0) })
+	assert.Panic(t, func() { df.XS("a", 2) })
+}
+
+func TestMultiIndex_DroplevelAndSwapLevel(t *testing.T) {
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+
+	d := df.Droplevel(0)
+	assert.Equal(t, d.MultiIndex().NLevels(), 1)
+	assert.Equal(t, d.Index().Name, "Inner")
+	assert.Equal(t, df.MultiIndex().NLevels(), 2)
+	assert.Panic(t, func() { d.Droplevel(0) })
+
+	s := df.SwapLevel(0, 1)
+	assert.Equal(t, fmt.Sprint(s.MultiIndex().Names()), "[Inner Outer]")
+	assert.Equal(t, fmt.Sprint(s.MultiIndex().Label(2)), "[1 b]")
+	assert.Equal(t, fmt.Sprint(df.MultiIndex().Names()), "[Outer Inner]")
+}
+
+func TestMultiIndex_Loc(t *testing.T) {
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+
+	assert.Equal(t, df.Loc([]any{"b", 2}).At(0, 0), 4)
+	assert.Equal(t, df.Loc("a").nrows, 2)
+	assert.Equal(t, df.Loc("a").MultiIndex().NLevels(), 2)
+	assert.Panic(t, func() { df.Loc([]any{"a", 3}) })
+
+	assert.Equal(t, df.LocRange("b", nil).nrows, 2)
+
+	r := df.Reindex([]any{[]any{"b", 1}, []any{"c", 1}}, 0)
+	assert.Equal(t, r.At(0, 0), 3)
+	assert.Equal(t, r.At(1, 0), 0)
+	assert.Equal(t, fmt.Sprint(r.MultiIndex().Label(1)), "[c 1]")
+	assert.Panic(t, func() { df.Reindex([]any{"a"}, nil) })
+}
+
+func TestMultiIndex_Reshape(t *testing.T) {
+	df := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+
+	both := Concat([]DataFrame{df, df.Slice(0, 1)})
+	assert.Equal(t, both.nrows, 5)
+	assert.Equal(t, fmt.Sprint(both.MultiIndex().Label(4)), "[a 1]")
+	assert.Panic(t, func() { Concat([]DataFrame{df, df.ResetIndex()}) })
+
+	tr := df.Transpose()
+	assert.Equal(t, fmt.Sprint(tr.Names()), "[a_1 a_2 b_1 b_2]")
+
+	un := df.Stack().Unstack(2)
+	assert.Equal(t, un.MultiIndex().NLevels(), 2)
+	assert.Equal(t, un.Column("Value").Val(3), 4)
+
+	// a MultiIndex built from the keys of a grouped result
+	g := df.ResetIndex()
+	g.Append(series.New([]string{"x", "y", "x", "y"}, series.String, "K"))
+	keyed := g.SetIndex(*g.Column("K"), *g.Column("Value"))
+	assert.Equal(t, keyed.Loc([]any{"y", 4}).nrows, 1)
+}
+
+func TestMultiIndex_JoinOnIndex(t *testing.T) {
+	left := New(series.New([]int{1, 2, 3, 4}, series.Int, "Value")).SetIndex(
+		series.New([]string{"a", "a", "b", "b"}, series.String, "Outer"),
+		series.New([]int{1, 2, 1, 2}, series.Int, "Inner"),
+	)
+	right := New(series.New([]string{"p", "q"}, series.String, "Other")).SetIndex(
+		series.New([]string{"b", "a"}, series.String, "Outer"),
+		series.New([]int{2, 1}, series.Int, "Inner"),
+	)
+
+	j := left.JoinIndex(right)
+	assert.Equal(t, j.nrows, 2)
+	assert.Panic(t, func() { left.JoinIndex(right.Droplevel(1)) })
+}
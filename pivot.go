@@ -0,0 +1,430 @@
+package golumn
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// marginsName labels the grand-total row and column added by PivotTable and Crosstab.
+const marginsName = "All"
+
+// PivotOptions defines the settings for DataFrame.PivotTable.
+type PivotOptions struct {
+	Index   []string // columns whose values become the rows of the table
+	Columns []string // columns whose values become the columns of the table
+	Values  []string // columns to aggregate; defaults to all numeric columns not used as keys
+
+	AggFunc   AggFunc // defaults to AggMean
+	FillValue any     // replaces empty cells; nil leaves them null
+
+	// Margins adds a last row and an "All" column holding totals over all rows. Key columns keep
+	// their type: the row's first key is "All" if it is a string column and null otherwise, and
+	// its other keys are null.
+	Margins bool
+	Sort    bool // orders rows and columns by key instead of first appearance
+	AsIndex bool // makes the Index columns the levels of the result's index instead of columns
+}
+
+// Pivot creates a pivot table with indexCol as rows, columnsCol as columns and valuesCol as cell values.
+// Aggregation is done by taking the first non-null value for the index/column pair.
+func (df DataFrame) Pivot(indexCol, columnsCol, valuesCol string) DataFrame {
+	return df.PivotTable(PivotOptions{
+		Index:   []string{indexCol},
+		Columns: []string{columnsCol},
+		Values:  []string{valuesCol},
+		AggFunc: AggFirst,
+	})
+}
+
+// PivotTable aggregates df into a table with one row per distinct Index key and one column per
+// distinct Columns key and value column. The result holds the Index columns, or index levels
+// with AsIndex, followed by the cell columns, named by joining the column key values with "_" and, when several Values are
+// given, prefixed by the value column name. Rows with a null key are left out.
+func (df DataFrame) PivotTable(opts PivotOptions) DataFrame {
+	if len(opts.Index) == 0 {
+		panic("no pivot index specified")
+	}
+
+	values := opts.Values
+	if len(values) == 0 {
+		used := make(map[string]bool)
+		for _, name := range append(slices.Clone(opts.Index), opts.Columns...) {
+			used[name] = true
+		}
+		for _, name := range df.SelectNumericNames() {
+			if !used[name] {
+				values = append(values, name)
+			}
+		}
+		if len(values) == 0 {
+			panic("no value columns to aggregate")
+		}
+	}
+
+	agg := opts.AggFunc
+	if agg == "" {
+		agg = AggMean
+	}
+
+	return buildPivot(pivotSpec{
+		index:   df.keyColumns(opts.Index),
+		columns: df.keyColumns(opts.Columns),
+		values:  df.keyColumns(values),
+		agg:     agg,
+		fill:    opts.FillValue,
+		margins: opts.Margins,
+		sort:    opts.Sort,
+		asIndex: opts.AsIndex,
+	})
+}
+
+// pivotSpec is the input to buildPivot, the engine shared by PivotTable and Crosstab.
+type pivotSpec struct {
+	index   []series.Series
+	columns []series.Series
+	values  []series.Series
+	agg     AggFunc
+	fill    any
+	margins bool
+	sort    bool
+	asIndex bool
+}
+
+// buildPivot groups rows by the index and column keys and aggregates each value series per cell.
+func buildPivot(p pivotSpec) DataFrame {
+	n := p.index[0].Len()
+	rindex := newKeyIndex(p.index, true)
+	rowGroup := rindex.rowGroups(n)
+
+	// without column keys every row falls into a single column group
+	cindex := newKeyIndex(p.columns, true)
+	colGroup := make([]int, n)
+	ncg := 1
+	if len(p.columns) > 0 {
+		colGroup = cindex.rowGroups(n)
+		ncg = len(cindex.groups)
+	}
+
+	cells := make([][][]int, len(rindex.groups))
+	for rg := range cells {
+		cells[rg] = make([][]int, ncg)
+	}
+	rowAll := make([][]int, len(rindex.groups))
+	colAll := make([][]int, ncg)
+	var all []int
+	for i := range n {
+		rg, cg := rowGroup[i], colGroup[i]
+		if rg == -1 || cg == -1 {
+			continue
+		}
+		cells[rg][cg] = append(cells[rg][cg], i)
+		rowAll[rg] = append(rowAll[rg], i)
+		colAll[cg] = append(colAll[cg], i)
+		all = append(all, i)
+	}
+
+	rorder := make([]int, len(rindex.groups))
+	for g := range rorder {
+		rorder[g] = g
+	}
+	corder := make([]int, ncg)
+	for g := range corder {
+		corder[g] = g
+	}
+	if p.sort {
+		rorder = rindex.sortedGroups()
+		if len(p.columns) > 0 {
+			corder = cindex.sortedGroups()
+		}
+	}
+
+	nrows := len(rorder)
+	if p.margins {
+		nrows++
+	}
+
+	keys := make([]series.Series, 0, len(p.index))
+	for k, c := range p.index {
+		vals := make([]any, 0, nrows)
+		for _, rg := range rorder {
+			vals = append(vals, c.Val(rindex.groups[rg][0]))
+		}
+		if p.margins {
+			vals = append(vals, marginKey(k, c.Type()))
+		}
+		keys = append(keys, series.New(vals, c.Type(), c.Name))
+	}
+
+	out := make([]series.Series, 0, len(p.index)+len(p.values)*(ncg+1))
+	if !p.asIndex {
+		out = append(out, keys...)
+	}
+
+	cell := func(v series.Series, positions []int) any {
+		if len(positions) == 0 {
+			return p.fill
+		}
+		return p.agg.apply(v, positions)
+	}
+
+	multi := len(p.values) > 1
+	for _, v := range p.values {
+		t := p.agg.resultType(v.Type())
+		for _, cg := range corder {
+			var key []any
+			if len(p.columns) > 0 {
+				key = cindex.key(cg)
+			}
+			vals := make([]any, 0, nrows)
+			for _, rg := range rorder {
+				vals = append(vals, cell(v, cells[rg][cg]))
+			}
+			if p.margins {
+				vals = append(vals, cell(v, colAll[cg]))
+			}
+			out = append(out, series.New(vals, t, pivotName(v.Name, multi, key)))
+		}
+
+		if p.margins && len(p.columns) > 0 {
+			vals := make([]any, 0, nrows)
+			for _, rg := range rorder {
+				vals = append(vals, cell(v, rowAll[rg]))
+			}
+			vals = append(vals, cell(v, all))
+			out = append(out, series.New(vals, t, pivotName(v.Name, multi, []any{marginsName})))
+		}
+	}
+
+	table := New(out...)
+	if p.asIndex {
+		table.index = newIndex(keys...)
+	}
+	return table
+}
+
+// marginKey returns the key of the margins row in the k-th key column of type t. Key columns
+// keep their type, so the row is labelled "All" in the first column only if that column holds
+// strings; every other key of the row is null.
+func marginKey(k int, t series.Type) any {
+	if k == 0 && t == series.String {
+		return marginsName
+	}
+	return nil
+}
+
+// pivotName builds a composite column name from the value name and column key values. Null
+// keys print as the empty string.
+func pivotName(value string, multi bool, key []any) string {
+	var parts []string
+	if multi || len(key) == 0 {
+		parts = append(parts, value)
+	}
+	for _, k := range key {
+		if k == nil {
+			parts = append(parts, "")
+		} else {
+			parts = append(parts, fmt.Sprint(k))
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// UnpivotOptions defines optional settings for DataFrame.Unpivot.
+type UnpivotOptions struct {
+	ValueVars []string // columns to melt; defaults to every column not in idVars
+	KeepNulls bool     // keep rows whose value is null instead of dropping them
+}
+
+// Unpivot melts the DataFrame from wide to long format. idVars are kept as identifier columns;
+// the value columns become variable/value pairs with names varName and valueName. The value
+// column takes the common type of the melted columns (see series.CommonType).
+func (df DataFrame) Unpivot(idVars []string, varName, valueName string, opts ...UnpivotOptions) DataFrame {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var opt UnpivotOptions
+	if len(opts) == 1 {
+		opt = opts[0]
+	}
+
+	ids := df.keyColumns(idVars)
+
+	// determine value columns
+	var valCols []series.Series
+	if len(opt.ValueVars) > 0 {
+		valCols = df.keyColumns(opt.ValueVars)
+	} else {
+		idSet := make(map[string]bool)
+		for _, v := range idVars {
+			idSet[v] = true
+		}
+		for _, s := range df.columns {
+			if !idSet[s.Name] {
+				valCols = append(valCols, s)
+			}
+		}
+	}
+
+	types := make([]series.Type, len(valCols))
+	for j, vc := range valCols {
+		types[j] = vc.Type()
+	}
+
+	idVals := make([][]any, len(ids))
+	var varVals []string
+	var values []any
+	for i := 0; i < df.nrows; i++ {
+		for _, vc := range valCols {
+			if vc.IsNull(i) && !opt.KeepNulls {
+				continue
+			}
+			for j, id := range ids {
+				idVals[j] = append(idVals[j], id.Val(i))
+			}
+			varVals = append(varVals, vc.Name)
+			values = append(values, vc.Val(i))
+		}
+	}
+
+	// prepare output: idVars..., varName (string), valueName (common type of the value columns)
+	outCols := make([]series.Series, 0, len(ids)+2)
+	for j, id := range ids {
+		outCols = append(outCols, series.New(idVals[j], id.Type(), id.Name))
+	}
+	outCols = append(outCols, series.New(varVals, series.String, varName))
+	outCols = append(outCols, series.New(values, series.CommonType(types...), valueName))
+
+	return New(outCols...)
+}
+
+// Normalize selects how Crosstab scales its cells.
+type Normalize int
+
+const (
+	NormalizeNone    Normalize = iota // raw counts or aggregates
+	NormalizeAll                      // divide by the grand total
+	NormalizeIndex                    // divide each row by its row total
+	NormalizeColumns                  // divide each column by its column total
+)
+
+// CrosstabOptions defines optional settings for Crosstab.
+type CrosstabOptions struct {
+	// Values, if set, is aggregated with AggFunc instead of counting occurrences.
+	Values  *series.Series
+	AggFunc AggFunc // defaults to AggSum when Values is set
+
+	Normalize Normalize
+	Margins   bool // adds a totals row and column, labelled as for PivotOptions.Margins
+}
+
+// Crosstab builds a contingency table from two series of equal length: one row per distinct
+// value of rows, one column per distinct value of cols, and cells counting co-occurrences.
+// Rows and columns are sorted by value; empty cells are 0 for counts and null otherwise.
+func Crosstab(rows, cols series.Series, opts ...CrosstabOptions) DataFrame {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var opt CrosstabOptions
+	if len(opts) == 1 {
+		opt = opts[0]
+	}
+	if rows.Len() != cols.Len() {
+		panic(fmt.Errorf("series lengths %v and %v do not match", rows.Len(), cols.Len()))
+	}
+
+	spec := pivotSpec{
+		index:   []series.Series{rows},
+		columns: []series.Series{cols},
+		margins: opt.Margins,
+		sort:    true,
+	}
+	if opt.Values == nil {
+		// count every row by aggregating a column of ones
+		ones := make([]int, rows.Len())
+		for i := range ones {
+			ones[i] = 1
+		}
+		spec.values = []series.Series{series.New(ones, series.Int, "count")}
+		spec.agg = AggSum
+		spec.fill = 0
+	} else {
+		if opt.Values.Len() != rows.Len() {
+			panic(fmt.Errorf("values length %v does not match series length %v", opt.Values.Len(), rows.Len()))
+		}
+		spec.values = []series.Series{*opt.Values}
+		spec.agg = opt.AggFunc
+		if spec.agg == "" {
+			spec.agg = AggSum
+		}
+	}
+
+	table := buildPivot(spec)
+	if opt.Normalize != NormalizeNone {
+		table = normalizeCrosstab(table, opt.Normalize, opt.Margins)
+	}
+	return table
+}
+
+// normalizeCrosstab divides the cells of a Crosstab result by the requested totals. The first
+// column holds the row labels; with margins, the last row and column hold the totals.
+func normalizeCrosstab(table DataFrame, how Normalize, margins bool) DataFrame {
+	nr := table.nrows
+	cells := table.columns[1:]
+	if margins {
+		nr--
+		cells = cells[:len(cells)-1]
+	}
+
+	cell := func(c series.Series, i int) float64 {
+		f, _ := toFloat(c.Val(i))
+		return f
+	}
+	rowTotals := make([]float64, table.nrows)
+	colTotals := make([]float64, len(cells))
+	grand := 0.0
+	for j, c := range cells {
+		for i := range nr {
+			v := cell(c, i)
+			rowTotals[i] += v
+			colTotals[j] += v
+			grand += v
+		}
+	}
+	if margins {
+		rowTotals[nr] = grand
+	}
+
+	out := []series.Series{table.columns[0]}
+	for j, c := range table.columns[1:] {
+		isMargin := margins && j == len(cells)
+		vals := make([]any, table.nrows)
+		for i := range table.nrows {
+			if c.IsNull(i) {
+				continue
+			}
+			var total float64
+			switch how {
+			case NormalizeAll:
+				total = grand
+			case NormalizeIndex:
+				total = rowTotals[i]
+			case NormalizeColumns:
+				if isMargin {
+					total = grand
+				} else {
+					total = colTotals[j]
+				}
+			default:
+				panic(fmt.Errorf("unknown normalization %v", how))
+			}
+			if total != 0 {
+				vals[i] = cell(c, i) / total
+			}
+		}
+		out = append(out, series.New(vals, series.Float, c.Name))
+	}
+	return New(out...)
+}
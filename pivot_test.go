@@ -0,0 +1,183 @@
+package golumn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestPivotTable_SumWithMargins(t *testing.T) {
+	df := New(
+		series.New([]string{"north", "south", "north", "north", "south"}, series.String, "region"),
+		series.New([]int{2024, 2024, 2025, 2024, 2025}, series.Int, "year"),
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "product"),
+		series.New([]int{10, 20, 30, 40, 50}, series.Int, "units"),
+		series.New([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, series.Float, "price"),
+	)
+
+	pt := df.PivotTable(PivotOptions{
+		Index:   []string{"region"},
+		Columns: []string{"year"},
+		Values:  []string{"units"},
+		AggFunc: AggSum,
+		Margins: true,
+	})
+
+	expected := "   region  2024  2025  All\n" +
+		"0   north    50    30   80\n" +
+		"1   south    20    50   70\n" +
+		"2     All    70    80  150"
+	assert.Equal(t, pt.String(), expected)
+	assert.Equal(t, pt.Column("2024").Type(), series.Int)
+
+	// non-string keys keep their type and leave the margins key null
+	byYear := df.PivotTable(PivotOptions{
+		Index:   []string{"year"},
+		Values:  []string{"units"},
+		AggFunc: AggSum,
+		Margins: true,
+	})
+	assert.Equal(t, byYear.Column("year").Type(), series.Int)
+	assert.Equal(t, byYear.Column("year").IsNull(2), true)
+	assert.Equal(t, byYear.Column("units").Val(2), 150)
+}
+
+func TestPivotTable_MultiKeysFillAndSort(t *testing.T) {
+	df := New(
+		series.New([]string{"north", "south", "north", "north", "south"}, series.String, "region"),
+		series.New([]int{2024, 2024, 2025, 2024, 2025}, series.Int, "year"),
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "product"),
+		series.New([]int{10, 20, 30, 40, 50}, series.Int, "units"),
+		series.New([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, series.Float, "price"),
+	)
+
+	pt := df.PivotTable(PivotOptions{
+		Index:     []string{"region", "product"},
+		Columns:   []string{"year"},
+		Values:    []string{"units", "price"},
+		AggFunc:   AggMean,
+		FillValue: 0.0,
+		Sort:      true,
+	})
+
+	names := pt.Names()
+	expectedNames := []string{"region", "product", "units_2024", "units_2025", "price_2024", "price_2025"}
+	assert.Equal(t, len(names), len(expectedNames))
+	for i := range expectedNames {
+		assert.Equal(t, names[i], expectedNames[i])
+	}
+
+	r, _ := pt.Shape()
+	assert.Equal(t, r, 4)
+	// sorted: (north, a), (north, b), (south, a), (south, b)
+	assert.Equal(t, pt.Column("product").Val(1), "b")
+	assert.Equal(t, pt.Column("region").Val(2), "south")
+	assert.Equal(t, pt.Column("units_2025").Val(1), 0.0)
+	assert.Equal(t, pt.Column("price_2025").Val(2), 5.0)
+
+	// AsIndex moves the keys into the index levels
+	keyed := df.PivotTable(PivotOptions{
+		Index:   []string{"region", "product"},
+		Columns: []string{"year"},
+		Values:  []string{"units"},
+		AggFunc: AggSum,
+		Sort:    true,
+		AsIndex: true,
+	})
+	assert.Equal(t, fmt.Sprint(keyed.Names()), "[2024 2025]")
+	assert.Equal(t, fmt.Sprint(keyed.MultiIndex().Names()), "[region product]")
+	assert.Equal(t, keyed.Index().Val(2), "south")
+	assert.Equal(t, keyed.Loc([]any{"north", "b"}).At(0, 0), 40)
+}
+
+func TestPivotTable_DefaultsAndMultiColumnKeys(t *testing.T) {
+	df := New(
+		series.New([]string{"north", "south", "north", "north", "south"}, series.String, "region"),
+		series.New([]int{2024, 2024, 2025, 2024, 2025}, series.Int, "year"),
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "product"),
+		series.New([]int{10, 20, 30, 40, 50}, series.Int, "units"),
+		series.New([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, series.Float, "price"),
+	)
+
+	pt := df.PivotTable(PivotOptions{
+		Index:   []string{"region"},
+		Columns: []string{"year", "product"},
+		AggFunc: AggCount,
+	})
+
+	// default values are the numeric non-key columns: units and price
+	assert.Equal(t, pt.Names()[1], "units_2024_a")
+	assert.Equal(t, pt.Column("units_2024_b").Val(1), 1)
+	assert.Equal(t, pt.Column("price_2025_a").IsNull(1), false)
+	assert.Equal(t, pt.Column("price_2024_a").IsNull(1), true)
+}
+
+func TestCrosstab_Counts(t *testing.T) {
+	sex := series.New([]string{"m", "f", "m", "f", "m"}, series.String, "sex")
+	smoker := series.New([]bool{true, false, false, false, true}, series.Boolean, "smoker")
+
+	ct := Crosstab(sex, smoker, CrosstabOptions{Margins: true})
+	expected := "   sex  false  true  All\n" +
+		"0    f      2     0    2\n" +
+		"1    m      1     2    3\n" +
+		"2  All      3     2    5"
+	assert.Equal(t, ct.String(), expected)
+}
+
+func TestCrosstab_NormalizeAndValues(t *testing.T) {
+	a := series.New([]string{"x", "x", "y", "y"}, series.String, "a")
+	b := series.New([]string{"p", "q", "p", "p"}, series.String, "b")
+
+	byRow := Crosstab(a, b, CrosstabOptions{Normalize: NormalizeIndex})
+	assert.Equal(t, byRow.Column("p").Val(0), 0.5)
+	assert.Equal(t, byRow.Column("p").Val(1), 1.0)
+	assert.Equal(t, byRow.Column("q").Val(1), 0.0)
+
+	all := Crosstab(a, b, CrosstabOptions{Normalize: NormalizeAll, Margins: true})
+	assert.Equal(t, all.Column("p").Val(2), 0.75)
+	assert.Equal(t, all.Column("All").Val(2), 1.0)
+
+	byCol := Crosstab(a, b, CrosstabOptions{Normalize: NormalizeColumns, Margins: true})
+	assert.Equal(t, byCol.Column("p").Val(1), 2.0/3.0)
+	assert.Equal(t, byCol.Column("All").Val(0), 0.5)
+
+	v := series.New([]float64{1.5, 2.5, 3.0, 5.0}, series.Float, "v")
+	mean := Crosstab(a, b, CrosstabOptions{Values: &v, AggFunc: AggMean})
+	assert.Equal(t, mean.Column("p").Val(1), 4.0)
+	assert.Equal(t, mean.Column("q").IsNull(1), true)
+}
+
+func TestUnpivot_ValueVarsMixedTypesAndNulls(t *testing.T) {
+	score := series.New([]float64{1.5, 0}, series.Float, "score")
+	score.Elem(1).Set(nil)
+	df := New(
+		series.New([]string{"a", "b"}, series.String, "id"),
+		series.New([]int{10, 20}, series.Int, "count"),
+		score,
+		series.New([]string{"x", "y"}, series.String, "note"),
+	)
+
+	numeric := df.Unpivot([]string{"id"}, "var", "value", UnpivotOptions{ValueVars: []string{"count", "score"}})
+	r, c := numeric.Shape()
+	assert.Equal(t, r, 3)
+	assert.Equal(t, c, 3)
+	assert.Equal(t, numeric.Column("value").Type(), series.Float)
+	assert.Equal(t, numeric.Column("value").Val(0), 10.0)
+	assert.Equal(t, numeric.Column("value").Val(1), 1.5)
+
+	kept := df.Unpivot([]string{"id"}, "var", "value", UnpivotOptions{ValueVars: []string{"count", "score"}, KeepNulls: true})
+	r, _ = kept.Shape()
+	assert.Equal(t, r, 4)
+	assert.Equal(t, kept.Column("var").Val(3), "score")
+	assert.Equal(t, kept.Column("value").IsNull(3), true)
+
+	mixed := df.Unpivot([]string{"id"}, "var", "value")
+	r, _ = mixed.Shape()
+	assert.Equal(t, r, 5)
+	assert.Equal(t, mixed.Column("value").Type(), series.String)
+	assert.Equal(t, mixed.Column("value").Val(0), "10")
+	assert.Equal(t, mixed.Column("value").Val(2), "x")
+}
@@ -0,0 +1,209 @@
+package golumn
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chriso345/golumn/series"
+)
+
+// Transpose swaps rows and columns: each row becomes a column named after its index label and
+// the former column names become the index. Mixed column types are promoted to a common type
+// (see series.CommonType); nulls are preserved.
+func (df DataFrame) Transpose() DataFrame {
+	if df.ncols == 0 || df.nrows == 0 {
+		return DataFrame{}
+	}
+
+	types := make([]series.Type, df.ncols)
+	for j, c := range df.columns {
+		types[j] = c.Type()
+	}
+	t := series.CommonType(types...)
+
+	cols := make([]series.Series, df.nrows)
+	for i := range df.nrows {
+		vals := make([]any, df.ncols)
+		for j, c := range df.columns {
+			vals[j] = c.Val(i)
+		}
+		cols[i] = series.New(vals, t, df.index.labelName(i))
+	}
+
+	out := New(cols...)
+	out.index = newIndex(series.New(df.Names(), series.String, "Index"))
+	return out
+}
+
+// Stack moves the columns into the rows, producing one row per original cell. The former
+// column names become a new innermost index level, named "level_N" after its position, below
+// the original index labels, and the cells form a single "value" column of the columns' common
+// type. Null cells are kept.
+func (df DataFrame) Stack() DataFrame {
+	types := make([]series.Type, df.ncols)
+	for j, c := range df.columns {
+		types[j] = c.Type()
+	}
+
+	n := df.nrows * df.ncols
+	positions := make([]int, 0, n)
+	names := make([]string, 0, n)
+	values := make([]any, 0, n)
+	for i := range df.nrows {
+		for _, c := range df.columns {
+			positions = append(positions, i)
+			names = append(names, c.Name)
+			values = append(values, c.Val(i))
+		}
+	}
+
+	out := New(series.New(values, series.CommonType(types...), "value"))
+	inner := series.New(names, series.String, fmt.Sprintf("level_%d", df.index.NLevels()))
+	out.index = newIndex(append(df.index.take(positions).levels, inner)...)
+	return out
+}
+
+// Unstack is the inverse of Stack: the distinct labels of the given index level become columns
+// and the rows are regrouped by the remaining levels, both in first-seen order. Null labels are
+// kept and group together. With a single value column the new columns are named after the level
+// labels; with several, each is prefixed by its value column name. Missing combinations are
+// null. It panics if the index has a single level or the same label tuple appears twice.
+func (df DataFrame) Unstack(level int) DataFrame {
+	df.index.checkLevel(level)
+	if df.index.NLevels() == 1 {
+		panic("cannot unstack the only level of the index")
+	}
+
+	rest := df.index.without(level)
+	rindex := newKeyIndex(rest.levels, false)
+	cindex := newKeyIndex(df.index.levels[level:level+1], false)
+	rowGroup := rindex.rowGroups(df.nrows)
+	colGroup := cindex.rowGroups(df.nrows)
+
+	// cells[cg][rg] is the row holding the value of each combination, or -1
+	cells := make([][]int, len(cindex.groups))
+	for cg := range cells {
+		cells[cg] = slices.Repeat([]int{-1}, len(rindex.groups))
+	}
+	for i := range df.nrows {
+		cell := &cells[colGroup[i]][rowGroup[i]]
+		if *cell != -1 {
+			panic(fmt.Errorf("index label %v appears more than once", df.index.Label(i)))
+		}
+		*cell = i
+	}
+
+	multi := df.ncols > 1
+	cols := make([]series.Series, 0, df.ncols*len(cells))
+	for _, v := range df.columns {
+		for cg, positions := range cells {
+			c := v.Take(positions)
+			c.Name = pivotName(v.Name, multi, cindex.key(cg))
+			cols = append(cols, c)
+		}
+	}
+
+	firsts := make([]int, len(rindex.groups))
+	for rg, rows := range rindex.groups {
+		firsts[rg] = rows[0]
+	}
+	out := New(cols...)
+	out.index = rest.take(firsts)
+	return out
+}
+
+// ExplodeOptions defines optional settings for DataFrame.Explode and DataFrame.Implode.
+type ExplodeOptions struct {
+	Sep string // delimiter between values; defaults to ","
+}
+
+// explodeSep returns the separator from optional ExplodeOptions.
+func explodeSep(opts []ExplodeOptions) string {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	if len(opts) == 1 && opts[0].Sep != "" {
+		return opts[0].Sep
+	}
+	return ","
+}
+
+// Explode splits the delimited values of a string column into separate rows, repeating the
+// other columns and the index for each part. Null values produce a single null row.
+// List-valued columns are not supported as the series package has no list type.
+func (df DataFrame) Explode(column string, opts ...ExplodeOptions) DataFrame {
+	sep := explodeSep(opts)
+	ci := df.columnIndex(column)
+	if ci == -1 {
+		panic(fmt.Errorf("column %v not found", column))
+	}
+	col := df.columns[ci]
+	if col.Type() != series.String {
+		panic(fmt.Errorf("cannot explode column %v of type %v", column, col.Type()))
+	}
+
+	var positions []int
+	var parts []any
+	for i := range df.nrows {
+		if col.IsNull(i) {
+			positions = append(positions, i)
+			parts = append(parts, nil)
+			continue
+		}
+		for _, part := range strings.Split(col.Val(i).(string), sep) {
+			positions = append(positions, i)
+			parts = append(parts, part)
+		}
+	}
+
+	out := df.takeRows(positions)
+	out.columns[ci] = series.New(parts, series.String, column)
+	return out
+}
+
+// Implode is the inverse of Explode: rows that agree on every other column are merged into one,
+// joining the non-null values of column with the separator. Groups keep the order and index
+// label of their first row; a group with only null values stays null.
+func (df DataFrame) Implode(column string, opts ...ExplodeOptions) DataFrame {
+	sep := explodeSep(opts)
+	ci := df.columnIndex(column)
+	if ci == -1 {
+		panic(fmt.Errorf("column %v not found", column))
+	}
+	col := df.columns[ci]
+
+	keys := slices.Delete(slices.Clone(df.columns), ci, ci+1)
+	var groups [][]int
+	if len(keys) == 0 {
+		all := make([]int, df.nrows)
+		for i := range all {
+			all[i] = i
+		}
+		groups = [][]int{all}
+	} else {
+		groups = newKeyIndex(keys, false).groups
+	}
+
+	first := make([]int, len(groups))
+	joined := make([]any, len(groups))
+	for g, rows := range groups {
+		first[g] = rows[0]
+		var parts []string
+		for _, i := range rows {
+			if col.IsNull(i) {
+				continue
+			}
+			if v, ok := series.AsString(col.Elem(i)); ok {
+				parts = append(parts, v)
+			}
+		}
+		if len(parts) > 0 {
+			joined[g] = strings.Join(parts, sep)
+		}
+	}
+
+	out := df.takeRows(first)
+	out.columns[ci] = series.New(joined, series.String, column)
+	return out
+}
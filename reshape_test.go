@@ -0,0 +1,101 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestTranspose(t *testing.T) {
+	b := series.New([]float64{1.5, 2.5}, series.Float, "b")
+	b.Elem(1).Set(nil)
+	df := New(series.New([]int{1, 2}, series.Int, "a"), b)
+	df = df.SetIndex(series.New([]string{"x", "y"}, series.String, "key"))
+
+	tr := df.Transpose()
+	expected := "     x  y\na    1  2\nb  1.5   "
+	assert.Equal(t, tr.String(), expected)
+	assert.Equal(t, tr.Column("x").Type(), series.Float)
+	assert.Equal(t, tr.Column("y").IsNull(1), true)
+
+	back := tr.Transpose()
+	assert.Equal(t, back.Names()[1], "b")
+	assert.Equal(t, back.Column("a").Val(1), 2.0)
+}
+
+func TestStackUnstack(t *testing.T) {
+	q2 := series.New([]int{3, 4}, series.Int, "q2")
+	q2.Elem(0).Set(nil)
+	df := New(series.New([]int{1, 2}, series.Int, "q1"), q2)
+	df = df.SetIndex(series.New([]string{"north", "south"}, series.String, "region"))
+
+	st := df.Stack()
+	r, c := st.Shape()
+	assert.Equal(t, r, 4)
+	assert.Equal(t, c, 1)
+	assert.Equal(t, st.MultiIndex().NLevels(), 2)
+	assert.Equal(t, st.MultiIndex().Level(0).Val(1), "north")
+	assert.Equal(t, st.MultiIndex().Level(1).Name, "level_1")
+	assert.Equal(t, st.MultiIndex().Level(1).Val(1), "q2")
+	assert.Equal(t, st.Column("value").IsNull(1), true)
+	assert.Equal(t, st.Column("value").Val(3), 4)
+
+	un := st.Unstack(1)
+	assert.Equal(t, un.String(), df.String())
+	assert.Equal(t, un.Index().Name, "region")
+	assert.Equal(t, un.Column("q2").IsNull(0), true)
+
+	// unstacking the outer level pivots the regions out instead
+	byRegion := st.Unstack(0)
+	assert.Equal(t, byRegion.Index().Name, "level_1")
+	assert.Equal(t, byRegion.Column("south").Val(1), 4)
+
+	assert.Panic(t, func() { df.Unstack(0) })
+	assert.Panic(t, func() { Concat([]DataFrame{st, st}).Unstack(1) })
+}
+
+func TestStackUnstack_NullLabels(t *testing.T) {
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "a"),
+		series.New([]int{4, 5, 6}, series.Int, "b"),
+	).SetIndex(series.New([]any{"x", nil, "z"}, series.String, "key"))
+
+	un := df.Stack().Unstack(1)
+	assert.Equal(t, un.String(), df.String())
+	assert.Equal(t, un.Index().IsNull(1), true)
+	assert.Equal(t, un.Column("b").Val(1), 5)
+}
+
+func TestExplodeImplode(t *testing.T) {
+	colors := series.New([]string{"red;green;blue", "", "yellow"}, series.String, "colors")
+	colors.Elem(1).Set(nil)
+	df := New(
+		series.New([]int{1, 2, 3}, series.Int, "id"),
+		colors,
+	)
+
+	ex := df.Explode("colors", ExplodeOptions{Sep: ";"})
+	expected := "   id  colors\n0   1     red\n0   1   green\n0   1    blue\n1   2        \n2   3  yellow"
+	assert.Equal(t, ex.String(), expected)
+	assert.Equal(t, ex.Column("colors").IsNull(3), true)
+
+	im := ex.Implode("colors", ExplodeOptions{Sep: ";"})
+	assert.Equal(t, im.String(), df.String())
+	assert.Equal(t, im.Column("colors").IsNull(1), true)
+}
+
+func TestExplode_DefaultSepAndNonString(t *testing.T) {
+	df := New(series.New([]string{"a,b"}, series.String, "tags"))
+	ex := df.Explode("tags")
+	r, _ := ex.Shape()
+	assert.Equal(t, r, 2)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic exploding a non-string column")
+		}
+	}()
+	_ = New(series.New([]int{1}, series.Int, "n")).Explode("n")
+}
@@ -2,6 +2,7 @@ package golumn
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/chriso345/golumn/series"
 )
@@ -39,16 +40,18 @@ func (row Row) Get(name string) any {
 	return col.Val(row.index)
 }
 
-// Set sets the value at the specified column name
+// Set sets the value at the specified column name; a nil value makes it null.
 func (row Row) Set(name string, value any) {
 	col := row.parent.Column(name)
 	if col == nil {
 		panic(fmt.Errorf("column %s not found", name))
 	}
-	if col.Type() != series.InferType(value) {
+	if value != nil && col.Type() != series.InferType(value) {
 		panic(fmt.Errorf("type mismatch: expected %v, got %T", col.Type(), value))
 	}
-	col.Elem(row.index).Set(value)
+	if err := col.SetAt(row.index, value); err != nil {
+		panic(err)
+	}
 }
 
 // JoinRows creates a DataFrame from a slice of Row.
@@ -71,12 +74,16 @@ func JoinRows(rows []Row) DataFrame {
 			cols[i] = series.New(make([]bool, nrows), series.Boolean, rows[0].parent.columns[i].Name)
 		case series.String:
 			cols[i] = series.New(make([]string, nrows), series.String, rows[0].parent.columns[i].Name)
+		case series.Datetime:
+			cols[i] = series.New(make([]time.Time, nrows), series.Datetime, rows[0].parent.columns[i].Name)
 		default:
 			panic(fmt.Errorf("unsupported series type: %v", rows[0].parent.columns[i].Type()))
 		}
 
 		for j, row := range rows {
-			cols[i].Elem(j).Set(row.At(i))
+			if err := cols[i].SetAt(j, row.At(i)); err != nil {
+				panic(err)
+			}
 		}
 	}
 
@@ -57,6 +57,13 @@ func TestRow_Set(t *testing.T) {
 
 	assert.Equal(t, df.At(1, 0), 20)
 	assert.Equal(t, df.At(1, 1), "Z")
+
+	row.Set("Integers", nil)
+	assert.Equal(t, df.Column("Integers").IsNull(1), true)
+	row.Set("Integers", 21)
+	assert.Equal(t, df.Column("Integers").IsNull(1), false)
+	assert.Equal(t, df.At(1, 0), 21)
+	assert.Panic(t, func() { row.Set("Integers", "x") })
 }
 
 func TestJoinRows(t *testing.T) {
@@ -0,0 +1,174 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// datetimeLayouts are tried in order when parsing strings as datetimes without a Format.
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// CastOptions defines optional settings for Series.Cast.
+type CastOptions struct {
+	// Strict makes Cast return a CastError if any value cannot be converted. By default such
+	// values become null.
+	Strict bool
+	// Format is the time layout used to parse and print datetimes (see time.Layout), or the fmt
+	// verb used to print numbers as strings, such as "%.2f", applied as NumberFormat.Format is. By
+	// default datetimes use RFC 3339 and numbers their shortest exact form.
+	Format string
+}
+
+// CastError reports the rows of a series whose values could not be converted in a strict Cast.
+type CastError struct {
+	Name string // name of the series
+	From Type   // type of the series
+	To   Type   // type requested
+	Rows []int  // positions of the values that failed to convert
+}
+
+func (e *CastError) Error() string {
+	return fmt.Sprintf("cannot cast %d values of %v from %v to %v at rows %v", len(e.Rows), e.Name, e.From, e.To, e.Rows)
+}
+
+// Cast converts the series to type t. Strings are parsed (blank strings become null), numbers
+// convert between each other, booleans map to 0 and 1 and numbers to false for zero and true
+// otherwise, and datetimes convert to and from Unix seconds. Floats with a fractional part do
+// not convert to Int. Casting between Boolean and Datetime is not supported and panics.
+func (s Series) Cast(t Type, opts ...CastOptions) (Series, error) {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var o CastOptions
+	if len(opts) == 1 {
+		o = opts[0]
+	}
+	if (s.t == Boolean && t == Datetime) || (s.t == Datetime && t == Boolean) {
+		panic(fmt.Errorf("cannot cast %v to %v", s.t, t))
+	}
+
+	vals := make([]any, s.Len())
+	var failed []int
+	for i := range vals {
+		if s.IsNull(i) {
+			continue
+		}
+		v, ok := convert(s.Val(i), t, o.Format)
+		if !ok {
+			failed = append(failed, i)
+			continue
+		}
+		vals[i] = v
+	}
+
+	if o.Strict && len(failed) > 0 {
+		return Series{}, &CastError{Name: s.Name, From: s.t, To: t, Rows: failed}
+	}
+	return New(vals, t, s.Name), nil
+}
+
+// convert converts a single non-null value to type t. It returns nil and true for values that
+// convert to null, such as blank strings parsed into other types, and false if v cannot be
+// converted. Strings cast to String are returned unchanged.
+func convert(v any, t Type, format string) (any, bool) {
+	if str, ok := v.(string); ok && t != String {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			return nil, true
+		}
+		return parseString(str, t, format)
+	}
+
+	switch t {
+	case String:
+		return formatValue(v, format), true
+	case Int:
+		switch x := v.(type) {
+		case int:
+			return x, true
+		case float64:
+			if x != math.Trunc(x) || x < math.MinInt64 || x >= math.MaxInt64 {
+				return nil, false
+			}
+			return int(x), true
+		case bool:
+			if x {
+				return 1, true
+			}
+			return 0, true
+		case time.Time:
+			return int(x.Unix()), true
+		}
+	case Float:
+		switch x := v.(type) {
+		case time.Time:
+			return float64(x.UnixNano()) / 1e9, true
+		default:
+			return numericValue(x), true
+		}
+	case Boolean:
+		return numericValue(v) != 0, true
+	case Datetime:
+		switch x := v.(type) {
+		case int:
+			return time.Unix(int64(x), 0).UTC(), true
+		case float64:
+			sec, frac := math.Modf(x)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+		case time.Time:
+			return x, true
+		}
+	}
+	panic(fmt.Errorf("cannot cast %T to %v", v, t))
+}
+
+// parseString parses a trimmed, non-empty string as a value of a non-string type t.
+func parseString(str string, t Type, format string) (any, bool) {
+	switch t {
+	case Int:
+		if n, err := strconv.Atoi(str); err == nil {
+			return n, true
+		}
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, false
+		}
+		return convert(f, Int, format)
+	case Float:
+		f, err := strconv.ParseFloat(str, 64)
+		return f, err == nil
+	case Boolean:
+		b, err := strconv.ParseBool(str)
+		return b, err == nil
+	case Datetime:
+		layouts := datetimeLayouts
+		if format != "" {
+			layouts = []string{format}
+		}
+		for _, layout := range layouts {
+			if d, err := time.Parse(layout, str); err == nil {
+				return d, true
+			}
+		}
+		return nil, false
+	default:
+		panic(fmt.Errorf("cannot cast string to %v", t))
+	}
+}
+
+// formatValue prints a non-string value, using format as a time layout for datetimes and as a
+// fmt verb for numbers.
+func formatValue(v any, format string) string {
+	if d, ok := v.(time.Time); ok && format != "" {
+		return d.Format(format)
+	}
+	return FormatValue(v, NumberFormat{Format: format})
+}
@@ -0,0 +1,111 @@
+package series
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestSeries_CastFromString(t *testing.T) {
+	s := New([]string{"1", " 2 ", "", "3.0", "x"}, String, "s")
+
+	ints, err := s.Cast(Int)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ints.Type(), Int)
+	expected := []any{1, 2, nil, 3, nil}
+	for i := range expected {
+		assert.Equal(t, ints.Val(i), expected[i])
+	}
+
+	_, err = s.Cast(Int, CastOptions{Strict: true})
+	var castErr *CastError
+	assert.Equal(t, errors.As(err, &castErr), true)
+	assert.Equal(t, len(castErr.Rows), 1)
+	assert.Equal(t, castErr.Rows[0], 4)
+	assert.Equal(t, err.Error(), "cannot cast 1 values of s from string to int at rows [4]")
+
+	floats, _ := New([]string{"1.5", "-2"}, String, "f").Cast(Float)
+	assert.Equal(t, floats.Val(0), any(1.5))
+	assert.Equal(t, floats.Val(1), any(-2.0))
+
+	bools, _ := New([]string{"true", "0", "maybe"}, String, "b").Cast(Boolean)
+	expected = []any{true, false, nil}
+	for i := range expected {
+		assert.Equal(t, bools.Val(i), expected[i])
+	}
+
+	dates, _ := New([]string{"2024-03-01", "2024-03-01T10:30:00Z", "March"}, String, "d").Cast(Datetime)
+	assert.Equal(t, dates.Type(), Datetime)
+	assert.Equal(t, dates.Val(0), any(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, dates.Val(1), any(time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)))
+	assert.Equal(t, dates.IsNull(2), true)
+
+	custom, _ := New([]string{"01/03/2024"}, String, "d").Cast(Datetime, CastOptions{Format: "02/01/2006"})
+	assert.Equal(t, custom.Val(0), any(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// casting to String leaves the values untouched
+	same, _ := s.Cast(String, CastOptions{Strict: true})
+	for i := range s.Len() {
+		assert.Equal(t, same.Val(i), s.Val(i))
+	}
+}
+
+func TestSeries_CastBetweenTypes(t *testing.T) {
+	floats := New([]any{1.0, 2.5, nil}, Float, "f")
+
+	ints, err := floats.Cast(Int)
+	assert.Equal(t, err, nil)
+	expected := []any{1, nil, nil}
+	for i := range expected {
+		assert.Equal(t, ints.Val(i), expected[i])
+	}
+	_, err = floats.Cast(Int, CastOptions{Strict: true})
+	assert.NotEqual(t, err, nil)
+
+	strs, _ := floats.Cast(String)
+	assert.Equal(t, strs.Val(1), any("2.5"))
+	assert.Equal(t, strs.IsNull(2), true)
+	fixed, _ := floats.Cast(String, CastOptions{Format: "%.2f"})
+	assert.Equal(t, fixed.Val(0), any("1.00"))
+	cents, _ := New([]int{3, 42}, Int, "i").Cast(String, CastOptions{Format: "%.2f"})
+	assert.Equal(t, cents.Val(1), any("42.00"))
+
+	bools, _ := New([]int{0, 3}, Int, "i").Cast(Boolean)
+	assert.Equal(t, bools.Val(0), any(false))
+	assert.Equal(t, bools.Val(1), any(true))
+	back, _ := bools.Cast(Float)
+	assert.Equal(t, back.Val(1), any(1.0))
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	unix, _ := New([]time.Time{day}, Datetime, "d").Cast(Int)
+	assert.Equal(t, unix.Val(0), any(int(day.Unix())))
+	dates, _ := unix.Cast(Datetime)
+	assert.Equal(t, dates.Val(0), any(day))
+	formatted, _ := dates.Cast(String, CastOptions{Format: "2006-01-02"})
+	assert.Equal(t, formatted.Val(0), any("2024-03-01"))
+
+	assert.Panic(t, func() { _, _ = bools.Cast(Datetime) })
+}
+
+func TestSeries_Datetime(t *testing.T) {
+	a := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	b := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := New([]any{a, nil, b}, Datetime, "d")
+	assert.Equal(t, s.IsNull(1), true)
+	assert.Equal(t, InferType(a), Datetime)
+	assert.Equal(t, CommonType(Datetime, Datetime), Datetime)
+	assert.Equal(t, CommonType(Datetime, Int), String)
+
+	idx := s.SortedIndex()
+	expected := []int{2, 0, 1}
+	for i := range expected {
+		assert.Equal(t, idx[i], expected[i])
+	}
+
+	s.Append(b)
+	c := s.Slice(2, 4).Copy()
+	assert.Equal(t, c.Len(), 2)
+	assert.Equal(t, c.Val(1), any(b))
+}
@@ -0,0 +1,163 @@
+package series
+
+// CumOptions defines optional settings for the cumulative operations of a Series.
+type CumOptions struct {
+	// PropagateNA makes every result after the first null value null. By default nulls are
+	// skipped: they stay null in the result and the accumulation carries on past them.
+	PropagateNA bool
+}
+
+// CumSum returns the running sum of a numeric series. Float series give Float results, other
+// numeric series give Int results.
+func (s Series) CumSum(opts ...CumOptions) Series {
+	return s.cumulate("cumulative sum", opts, s.arithmeticType(),
+		func(acc, v int) int { return acc + v },
+		func(acc, v float64) float64 { return acc + v })
+}
+
+// CumProd returns the running product of a numeric series, typed like CumSum.
+func (s Series) CumProd(opts ...CumOptions) Series {
+	return s.cumulate("cumulative product", opts, s.arithmeticType(),
+		func(acc, v int) int { return acc * v },
+		func(acc, v float64) float64 { return acc * v })
+}
+
+// CumMax returns the running maximum of a numeric series, keeping its type.
+func (s Series) CumMax(opts ...CumOptions) Series {
+	return s.cumulate("cumulative maximum", opts, s.t,
+		func(acc, v int) int { return max(acc, v) },
+		func(acc, v float64) float64 { return max(acc, v) })
+}
+
+// CumMin returns the running minimum of a numeric series, keeping its type.
+func (s Series) CumMin(opts ...CumOptions) Series {
+	return s.cumulate("cumulative minimum", opts, s.t,
+		func(acc, v int) int { return min(acc, v) },
+		func(acc, v float64) float64 { return min(acc, v) })
+}
+
+// CumCount returns the running number of non-null values as an Int series without nulls.
+func (s Series) CumCount() Series {
+	vals := make([]int, s.Len())
+	n := 0
+	for i := range vals {
+		if s.IsValid(i) {
+			n++
+		}
+		vals[i] = n
+	}
+	return New(vals, Int, s.Name)
+}
+
+// cumulate folds the non-null values of s in order with fint or ffloat, depending on the type of
+// the result, and stores each running value at its position.
+func (s Series) cumulate(op string, opts []CumOptions, t Type, fint func(acc, v int) int, ffloat func(acc, v float64) float64) Series {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	s.mustBeNumeric(op)
+	propagate := len(opts) == 1 && opts[0].PropagateNA
+
+	out := make([]any, s.Len())
+	var iacc int
+	var facc float64
+	started := false
+	for i := range out {
+		if s.IsNull(i) {
+			if propagate {
+				break
+			}
+			continue
+		}
+		switch t {
+		case Float:
+			v, _ := AsFloat(s.Elem(i))
+			if started {
+				v = ffloat(facc, v)
+			}
+			facc, out[i] = v, v
+		default:
+			v, _ := AsInt(s.Elem(i))
+			if started {
+				v = fint(iacc, v)
+			}
+			iacc, out[i] = v, v
+		}
+		started = true
+	}
+	return New(out, t, s.Name)
+}
+
+// Shift moves the values of s by periods positions, forward for positive periods and backward
+// for negative ones. Vacated positions hold fill, or null when fill is nil.
+func (s Series) Shift(periods int, fill any) Series {
+	positions := make([]int, s.Len())
+	for i := range positions {
+		positions[i] = i - periods
+		if positions[i] >= s.Len() {
+			positions[i] = -1
+		}
+	}
+
+	res := s.Take(positions)
+	if fill != nil {
+		for i, pos := range positions {
+			if pos < 0 {
+				res.Elem(i).Set(fill)
+			}
+		}
+	}
+	return res
+}
+
+// Diff returns the difference between each value and the value periods positions before it.
+// Positions without a counterpart, or where either value is null, are null. Results are typed
+// like CumSum.
+func (s Series) Diff(periods int) Series {
+	s.mustBeNumeric("difference")
+	t := s.arithmeticType()
+	return s.pairwise(periods, t, func(cur, prev Element) any {
+		if t == Float {
+			a, _ := AsFloat(cur)
+			b, _ := AsFloat(prev)
+			return a - b
+		}
+		a, _ := AsInt(cur)
+		b, _ := AsInt(prev)
+		return a - b
+	})
+}
+
+// PctChange returns the fractional change between each value and the value periods positions
+// before it as a Float series. Changes from zero are null, as are positions Diff leaves null.
+func (s Series) PctChange(periods int) Series {
+	s.mustBeNumeric("percentage change")
+	return s.pairwise(periods, Float, func(cur, prev Element) any {
+		b := numericValue(prev.Get())
+		if b == 0 {
+			return nil
+		}
+		return numericValue(cur.Get())/b - 1
+	})
+}
+
+// pairwise combines each value with the value periods positions before it using fn.
+func (s Series) pairwise(periods int, t Type, fn func(cur, prev Element) any) Series {
+	out := make([]any, s.Len())
+	for i := range out {
+		j := i - periods
+		if j < 0 || j >= s.Len() || s.IsNull(i) || s.IsNull(j) {
+			continue
+		}
+		out[i] = fn(s.Elem(i), s.Elem(j))
+	}
+	return New(out, t, s.Name)
+}
+
+// arithmeticType returns the type of sums and differences of a numeric series.
+func (s Series) arithmeticType() Type {
+	if s.t == Float {
+		return Float
+	}
+	return Int
+}
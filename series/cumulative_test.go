@@ -0,0 +1,78 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestSeries_Cumulative(t *testing.T) {
+	s := New([]any{2, nil, 3, 1}, Int, "x")
+
+	expected := map[string][]any{
+		"sum":   {2, nil, 5, 6},
+		"prod":  {2, nil, 6, 6},
+		"max":   {2, nil, 3, 3},
+		"min":   {2, nil, 2, 1},
+		"count": {1, 1, 2, 3},
+	}
+	results := map[string]Series{
+		"sum":   s.CumSum(),
+		"prod":  s.CumProd(),
+		"max":   s.CumMax(),
+		"min":   s.CumMin(),
+		"count": s.CumCount(),
+	}
+	for name, vals := range expected {
+		assert.Equal(t, results[name].Type(), Int)
+		for i := range vals {
+			assert.Equal(t, results[name].Val(i), vals[i])
+		}
+	}
+
+	propagated := s.CumSum(CumOptions{PropagateNA: true})
+	assert.Equal(t, propagated.Val(0), any(2))
+	for i := 1; i < propagated.Len(); i++ {
+		assert.Equal(t, propagated.IsNull(i), true)
+	}
+
+	floats := New([]float64{0.5, 1.5}, Float, "f").CumSum()
+	assert.Equal(t, floats.Type(), Float)
+	assert.Equal(t, floats.Val(1), any(2.0))
+
+	bools := New([]bool{true, false, true}, Boolean, "b")
+	assert.Equal(t, bools.CumSum().Val(2), any(2))
+	assert.Equal(t, bools.CumMax().Type(), Boolean)
+	assert.Equal(t, bools.CumMin().Val(2), any(false))
+
+	assert.Panic(t, func() { New([]string{"a"}, String, "s").CumSum() })
+}
+
+func TestSeries_ShiftDiffPctChange(t *testing.T) {
+	s := New([]any{1, 2, nil, 8}, Int, "x")
+
+	shifted := s.Shift(1, nil)
+	expected := []any{nil, 1, 2, nil}
+	for i := range expected {
+		assert.Equal(t, shifted.Val(i), expected[i])
+	}
+
+	back := s.Shift(-2, 0)
+	expected = []any{nil, 8, 0, 0}
+	for i := range expected {
+		assert.Equal(t, back.Val(i), expected[i])
+	}
+
+	diff := s.Diff(1)
+	expected = []any{nil, 1, nil, nil}
+	for i := range expected {
+		assert.Equal(t, diff.Val(i), expected[i])
+	}
+	assert.Equal(t, s.Diff(2).Val(3), any(6))
+
+	pct := New([]float64{2, 3, 0, 1}, Float, "p").PctChange(1)
+	expected = []any{nil, 0.5, -1.0, nil}
+	for i := range expected {
+		assert.Equal(t, pct.Val(i), expected[i])
+	}
+}
@@ -2,7 +2,7 @@
 // one-dimensional labeled data with a single data type.
 //
 // A Series represents a sequence of elements that can be of type string, rune,
-// int, float, bool, and other primitive types. It is designed for use in
+// int, float, bool, datetime and other primitive types. It is designed for use in
 // columnar data manipulation and analysis, often within the context of data frames.
 //
 // Series support indexing, type conversion, filtering, and other common
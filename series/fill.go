@@ -0,0 +1,165 @@
+package series
+
+import (
+	"fmt"
+	"time"
+)
+
+// InterpolateMethod selects how Series.Interpolate estimates missing values.
+type InterpolateMethod string
+
+const (
+	InterpolateLinear  InterpolateMethod = "linear"  // straight line between neighbours, treating values as equally spaced
+	InterpolateNearest InterpolateMethod = "nearest" // value of the closest neighbour, the earlier one on ties
+	InterpolateIndex   InterpolateMethod = "index"   // straight line between neighbours, spaced by the index values
+)
+
+// InterpolateOptions defines optional settings for Series.Interpolate.
+type InterpolateOptions struct {
+	Index *Series // positions of the values for InterpolateIndex; numeric or datetime, without nulls
+}
+
+// FillForward returns a copy of the series with each null replaced by the last non-null value
+// before it. If limit is positive, at most limit consecutive nulls are filled after each value.
+func (s Series) FillForward(limit int) Series {
+	positions := make([]int, s.Len())
+	last := -1
+	for i := range positions {
+		if s.IsValid(i) {
+			last = i
+		}
+		positions[i] = fillSource(i, last, limit)
+	}
+	return s.Take(positions)
+}
+
+// FillBackward returns a copy of the series with each null replaced by the next non-null value
+// after it. If limit is positive, at most limit consecutive nulls are filled before each value.
+func (s Series) FillBackward(limit int) Series {
+	positions := make([]int, s.Len())
+	next := -1
+	for i := s.Len() - 1; i >= 0; i-- {
+		if s.IsValid(i) {
+			next = i
+		}
+		positions[i] = fillSource(i, next, limit)
+	}
+	return s.Take(positions)
+}
+
+// fillSource returns the position whose value fills position i, given the nearest non-null
+// position src in the fill direction (-1 if there is none), or -1 if i stays null.
+func fillSource(i, src, limit int) int {
+	if src == -1 || (limit > 0 && abs(i-src) > limit) {
+		return -1
+	}
+	return src
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FillWith returns a copy of the series with the null at each position i replaced by fn(i).
+// Nulls for which fn returns nil stay null.
+func (s Series) FillWith(fn func(i int) any) Series {
+	vals := make([]any, s.Len())
+	for i := range vals {
+		if s.IsValid(i) {
+			vals[i] = s.Val(i)
+		} else {
+			vals[i] = fn(i)
+		}
+	}
+	res := New(vals, s.t, s.Name)
+	res.format = s.format
+	return res
+}
+
+// Interpolate returns a copy of a numeric series with the nulls between two non-null values
+// estimated from them. Nulls before the first or after the last value stay null. Linear and
+// index interpolation give a Float series; nearest keeps the type of the series.
+func (s Series) Interpolate(method InterpolateMethod, opts ...InterpolateOptions) Series {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	s.mustBeNumeric("interpolation")
+
+	// prev and next hold the nearest non-null positions on either side of each position
+	n := s.Len()
+	prev, next := make([]int, n), make([]int, n)
+	for i, last := 0, -1; i < n; i++ {
+		if s.IsValid(i) {
+			last = i
+		}
+		prev[i] = last
+	}
+	for i, last := n-1, -1; i >= 0; i-- {
+		if s.IsValid(i) {
+			last = i
+		}
+		next[i] = last
+	}
+
+	switch method {
+	case InterpolateNearest:
+		positions := make([]int, n)
+		for i := range positions {
+			positions[i] = -1
+			if p, q := prev[i], next[i]; p != -1 && q != -1 {
+				positions[i] = p
+				if q-i < i-p {
+					positions[i] = q
+				}
+			}
+		}
+		return s.Take(positions)
+	case InterpolateLinear, InterpolateIndex:
+	default:
+		panic(fmt.Errorf("unknown interpolation method %q", method))
+	}
+
+	x := func(i int) float64 { return float64(i) }
+	if method == InterpolateIndex {
+		if len(opts) == 0 || opts[0].Index == nil {
+			panic("index interpolation requires an Index")
+		}
+		x = indexPositions(*opts[0].Index, n)
+	}
+
+	y, _ := s.floats()
+	vals := make([]any, n)
+	for i := range vals {
+		p, q := prev[i], next[i]
+		switch {
+		case p == i:
+			vals[i] = y[i]
+		case p != -1 && q != -1:
+			vals[i] = y[p] + (y[q]-y[p])*(x(i)-x(p))/(x(q)-x(p))
+		}
+	}
+	res := New(vals, Float, s.Name)
+	res.format = s.format
+	return res
+}
+
+// indexPositions returns the value of a numeric or datetime index at each position as a float64.
+func indexPositions(index Series, n int) func(int) float64 {
+	if index.Len() != n {
+		panic(fmt.Errorf("index has length %v, expected %v", index.Len(), n))
+	}
+	if index.AnyNull() {
+		panic("index for interpolation must not contain nulls")
+	}
+	switch index.t {
+	case Datetime:
+		return func(i int) float64 { return float64(index.Val(i).(time.Time).UnixNano()) }
+	case Int, Float, Boolean:
+		return func(i int) float64 { return numericValue(index.Val(i)) }
+	default:
+		panic(fmt.Errorf("cannot interpolate over an index of type %v", index.t))
+	}
+}
@@ -0,0 +1,51 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestSeries_FillForwardBackward(t *testing.T) {
+	s := New([]any{nil, 1, nil, nil, nil, 5, nil}, Int, "x")
+
+	assert.Equal(t, s.FillForward(0).String(), "{x [<nil> 1 1 1 1 5 5] int}")
+	assert.Equal(t, s.FillForward(2).String(), "{x [<nil> 1 1 1 <nil> 5 5] int}")
+	assert.Equal(t, s.FillBackward(0).String(), "{x [1 1 5 5 5 5 <nil>] int}")
+	assert.Equal(t, s.FillBackward(1).String(), "{x [1 1 <nil> <nil> 5 5 <nil>] int}")
+
+	filled := s.FillForward(2)
+	assert.Equal(t, filled.IsNull(4), true)
+	assert.Equal(t, filled.IsNull(3), false)
+}
+
+func TestSeries_FillWith(t *testing.T) {
+	s := New([]any{"a", nil, "c", nil}, String, "s")
+	filled := s.FillWith(func(i int) any {
+		if i == 1 {
+			return "b"
+		}
+		return nil
+	})
+	assert.Equal(t, filled.String(), "{s [a b c <nil>] string}")
+	assert.Equal(t, filled.IsNull(3), true)
+	assert.Equal(t, s.IsNull(1), true)
+}
+
+func TestSeries_Interpolate(t *testing.T) {
+	s := New([]any{nil, 1, nil, nil, 7, nil}, Int, "x")
+
+	linear := s.Interpolate(InterpolateLinear)
+	assert.Equal(t, linear.Type(), Float)
+	assert.Equal(t, linear.String(), "{x [<nil> 1 3 5 7 <nil>] float}")
+
+	nearest := s.Interpolate(InterpolateNearest)
+	assert.Equal(t, nearest.String(), "{x [<nil> 1 1 7 7 <nil>] int}")
+
+	index := New([]float64{0, 1, 2, 10, 11, 12}, Float, "Index")
+	byIndex := s.Interpolate(InterpolateIndex, InterpolateOptions{Index: &index})
+	assert.Equal(t, byIndex.String(), "{x [<nil> 1 1.6 6.4 7 <nil>] float}")
+
+	assert.Panic(t, func() { s.Interpolate(InterpolateIndex) })
+	assert.Panic(t, func() { New([]string{"a"}, String, "s").Interpolate(InterpolateLinear) })
+}
@@ -0,0 +1,98 @@
+package series
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NumberFormat controls how the numbers of a series are printed. The zero NumberFormat prints
+// floats in the shortest form that parses back to the same value, e.g. 1.234e-06 or 1e+20.
+type NumberFormat struct {
+	// Format is a fmt verb such as "%.2f" or "%05d" and overrides Precision. Ints are printed as
+	// floats under a float verb; floats ignore a verb that only fits ints.
+	Format    string
+	Precision int // digits after the decimal point for floats, if positive
+}
+
+const (
+	intVerbs   = "bcdoOqxXUv"
+	floatVerbs = "beEfFgGxXv"
+)
+
+// verb returns the conversion character of the first directive in format, or 0 if it has none.
+func verb(format string) byte {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		for i++; i < len(format) && format[i] != '%'; i++ {
+			if c := format[i]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// fits reports whether the verb of f.Format is one of verbs.
+func (f NumberFormat) fits(verbs string) bool {
+	c := verb(f.Format)
+	return c != 0 && strings.IndexByte(verbs, c) >= 0
+}
+
+// FormatFloat prints v according to f.
+func (f NumberFormat) FormatFloat(v float64) string {
+	switch {
+	case f.fits(floatVerbs):
+		return fmt.Sprintf(f.Format, v)
+	case f.Precision > 0:
+		return strconv.FormatFloat(v, 'f', f.Precision, 64)
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// FormatValue prints a cell value: floats and ints according to f, datetimes in RFC 3339 and
+// nulls as the empty string.
+func FormatValue(v any, f NumberFormat) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return f.FormatFloat(x)
+	case int:
+		switch {
+		case f.fits(intVerbs):
+			return fmt.Sprintf(f.Format, x)
+		case f.fits(floatVerbs):
+			return fmt.Sprintf(f.Format, float64(x))
+		}
+		return strconv.Itoa(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case string:
+		return x
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// SetFormat sets the NumberFormat used when the values of the series are printed.
+// The format is kept by copies, slices and reorderings of the series.
+func (s *Series) SetFormat(f NumberFormat) {
+	s.format = f
+}
+
+// Format returns the NumberFormat used when the values of the series are printed.
+func (s Series) Format() NumberFormat {
+	return s.format
+}
+
+// FormatVal returns the value at index i printed with the series' NumberFormat; nulls are empty.
+func (s Series) FormatVal(i int) string {
+	return FormatValue(s.Val(i), s.format)
+}
@@ -0,0 +1,52 @@
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, FormatValue(0.000001234, NumberFormat{}), "1.234e-06")
+	assert.Equal(t, FormatValue(1e20, NumberFormat{}), "1e+20")
+	assert.Equal(t, FormatValue(0.1, NumberFormat{}), "0.1")
+	assert.Equal(t, FormatValue(2.5, NumberFormat{Precision: 3}), "2.500")
+	assert.Equal(t, FormatValue(1234.5, NumberFormat{Format: "%.1e"}), "1.2e+03")
+	assert.Equal(t, FormatValue(7, NumberFormat{Format: "%03d"}), "007")
+	assert.Equal(t, FormatValue(7, NumberFormat{Precision: 2}), "7")
+	assert.Equal(t, FormatValue(1, NumberFormat{Format: "%.2f"}), "1.00")
+	assert.Equal(t, FormatValue(2.5, NumberFormat{Format: "%03d"}), "2.5")
+	assert.Equal(t, FormatValue(2.5, NumberFormat{Format: "%03d", Precision: 2}), "2.50")
+	assert.Equal(t, FormatValue(nil, NumberFormat{}), "")
+	assert.Equal(t, FormatValue(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), NumberFormat{}), "2024-01-02T03:04:05Z")
+}
+
+func TestFloatToStringPreservesPrecision(t *testing.T) {
+	s := New([]float64{0.000001234, 1e20}, String, "s")
+	assert.Equal(t, s.Val(0), any("1.234e-06"))
+	assert.Equal(t, s.Val(1), any("1e+20"))
+
+	f := New([]float64{0.000001234}, Float, "f")
+	str, ok := AsString(f.Elem(0))
+	assert.Equal(t, ok, true)
+	assert.Equal(t, str, "1.234e-06")
+}
+
+func TestSeries_FormatIsKept(t *testing.T) {
+	s := New([]any{1.0, nil, 3.14159}, Float, "f")
+	s.SetFormat(NumberFormat{Precision: 2})
+	assert.Equal(t, s.FormatVal(2), "3.14")
+	assert.Equal(t, s.FormatVal(1), "")
+
+	assert.Equal(t, s.Copy().FormatVal(0), "1.00")
+	assert.Equal(t, s.Slice(2, 3).FormatVal(0), "3.14")
+	assert.Equal(t, s.Take([]int{2}).FormatVal(0), "3.14")
+	assert.Equal(t, s.DropNA().Format(), NumberFormat{Precision: 2})
+
+	// a float verb on an Int column prints the ints as floats
+	n := New([]int{1, 12}, Int, "n")
+	n.SetFormat(NumberFormat{Format: "%.2f"})
+	assert.Equal(t, n.FormatVal(0), "1.00")
+	assert.Equal(t, n.FormatVal(1), "12.00")
+}
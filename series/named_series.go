@@ -1,6 +1,9 @@
 package series
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // NewRangedSeries creates a new Series defined for a range of integers.
 func NewRangedSeries(start, end int, t Type, name string) Series {
@@ -23,6 +26,8 @@ func NewEmptySeries(t Type, size int, name string) Series {
 		return New(make([]bool, size), t, name)
 	case String:
 		return New(make([]string, size), t, name)
+	case Datetime:
+		return New(make([]time.Time, size), t, name)
 	case Runic:
 		panic("not implemented")
 	default:
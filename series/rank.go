@@ -0,0 +1,92 @@
+package series
+
+import (
+	"fmt"
+
+	"github.com/chriso345/golumn/internal/topn"
+)
+
+// RankMethod selects how Series.Rank assigns ranks to tied values.
+type RankMethod string
+
+const (
+	RankAverage RankMethod = "average" // mean of the positions the ties occupy
+	RankMin     RankMethod = "min"     // lowest position the ties occupy
+	RankMax     RankMethod = "max"     // highest position the ties occupy
+	RankFirst   RankMethod = "first"   // positions in order of appearance
+	RankDense   RankMethod = "dense"   // like RankMin, but ranks increase by one between groups
+)
+
+// Rank returns the 1-based ascending rank of each value as a Float series; nulls stay null.
+// With pct set, ranks are divided by the number of non-null values (or, for RankDense, by the
+// number of distinct values) so they fall in (0, 1].
+func (s Series) Rank(method RankMethod, pct bool) Series {
+	switch method {
+	case RankAverage, RankMin, RankMax, RankFirst, RankDense:
+	default:
+		panic(fmt.Errorf("unknown rank method %q", method))
+	}
+
+	order := s.SortedIndex()
+	valid := s.Len() - s.CountNulls()
+	out := make([]any, s.Len())
+	dense := 0
+	for lo := 0; lo < valid; {
+		hi := lo + 1
+		for hi < valid && s.compareAt(order[lo], order[hi]) == 0 {
+			hi++
+		}
+		dense++
+		for k := lo; k < hi; k++ {
+			var r float64
+			switch method {
+			case RankAverage:
+				r = float64(lo+1+hi) / 2
+			case RankMin:
+				r = float64(lo + 1)
+			case RankMax:
+				r = float64(hi)
+			case RankFirst:
+				r = float64(k + 1)
+			case RankDense:
+				r = float64(dense)
+			}
+			out[order[k]] = r
+		}
+		lo = hi
+	}
+
+	if pct {
+		total := float64(valid)
+		if method == RankDense {
+			total = float64(dense)
+		}
+		for i, r := range out {
+			if r != nil {
+				out[i] = r.(float64) / total
+			}
+		}
+	}
+	return New(out, Float, s.Name)
+}
+
+// NLargest returns the n largest non-null values in descending order. Ties keep their order of
+// appearance.
+func (s Series) NLargest(n int) Series {
+	return s.Take(s.topPositions(n, true))
+}
+
+// NSmallest returns the n smallest non-null values in ascending order, like NLargest.
+func (s Series) NSmallest(n int) Series {
+	return s.Take(s.topPositions(n, false))
+}
+
+// topPositions returns the positions of the n largest (or smallest) non-null values, best first.
+func (s Series) topPositions(n int, largest bool) []int {
+	return topn.Positions(n, s.Len(), s.IsValid, func(a, b int) int {
+		if largest {
+			return s.compareAt(a, b)
+		}
+		return s.compareAt(b, a)
+	})
+}
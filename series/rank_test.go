@@ -0,0 +1,68 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestSeries_SortedIndexOptions(t *testing.T) {
+	s := New([]any{3, 1, nil, 3, 2}, Int, "x")
+
+	cases := []struct {
+		opts     SortedIndexOptions
+		expected []int
+	}{
+		{SortedIndexOptions{}, []int{1, 4, 0, 3, 2}},
+		{SortedIndexOptions{Descending: true}, []int{0, 3, 4, 1, 2}},
+		{SortedIndexOptions{NullsFirst: true}, []int{2, 1, 4, 0, 3}},
+		{SortedIndexOptions{Descending: true, NullsFirst: true}, []int{2, 0, 3, 4, 1}},
+	}
+	for _, c := range cases {
+		idx := s.SortedIndex(c.opts)
+		for i := range c.expected {
+			assert.Equal(t, idx[i], c.expected[i])
+		}
+	}
+}
+
+func TestSeries_Rank(t *testing.T) {
+	s := New([]any{3, 1, nil, 3, 2}, Int, "x")
+
+	cases := []struct {
+		method   RankMethod
+		pct      bool
+		expected []any
+	}{
+		{RankAverage, false, []any{3.5, 1.0, nil, 3.5, 2.0}},
+		{RankMin, false, []any{3.0, 1.0, nil, 3.0, 2.0}},
+		{RankMax, false, []any{4.0, 1.0, nil, 4.0, 2.0}},
+		{RankFirst, false, []any{3.0, 1.0, nil, 4.0, 2.0}},
+		{RankDense, false, []any{3.0, 1.0, nil, 3.0, 2.0}},
+		{RankAverage, true, []any{0.875, 0.25, nil, 0.875, 0.5}},
+		{RankDense, true, []any{1.0, 1.0 / 3, nil, 1.0, 2.0 / 3}},
+	}
+	for _, c := range cases {
+		rank := s.Rank(c.method, c.pct)
+		for i := range c.expected {
+			assert.Equal(t, rank.Val(i), c.expected[i])
+		}
+	}
+
+	assert.Panic(t, func() { s.Rank("median", false) })
+}
+
+func TestSeries_NLargestNSmallest(t *testing.T) {
+	s := New([]any{3, 1, nil, 3, 2}, Int, "x")
+
+	largest := s.NLargest(2)
+	assert.Equal(t, largest.String(), "{x [3 3] int}")
+
+	smallest := s.NSmallest(2)
+	assert.Equal(t, smallest.String(), "{x [1 2] int}")
+
+	all := s.NLargest(10)
+	assert.Equal(t, all.String(), "{x [3 3 2 1] int}")
+
+	assert.Equal(t, s.NSmallest(0).Len(), 0)
+}
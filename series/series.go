@@ -1,7 +1,11 @@
 package series
 
 import (
+	"cmp"
 	"fmt"
+	"math"
+	"slices"
+	"time"
 )
 
 // Series is a collection of elements of the same type and
@@ -9,9 +13,12 @@ import (
 type Series struct {
 	Name     string
 	elements Elements
-	// valid is a compact validity bitset for nullable values. nil means all values are valid.
+	// valid is the validity bitset and the only source of truth for nulls; the NA flags of the
+	// elements mirror it. Every constructor allocates it, so only the zero Series has a nil bitset.
 	valid *Bitset
 	t     Type
+	// format controls how numbers are printed; see SetFormat.
+	format NumberFormat
 }
 
 // Elements is an interface that defines the methods that a collection of elements must implement
@@ -19,6 +26,7 @@ type Elements interface {
 	Elem(int) Element
 	Len() int
 	Values() []any
+	Swap(i, j int)
 }
 
 // Element is an interface that defines the methods that an element must implement
@@ -36,6 +44,7 @@ type intElements []intElement
 
 func (i intElements) Len() int           { return len(i) }
 func (i intElements) Elem(j int) Element { return &i[j] }
+func (i intElements) Swap(j, k int)      { i[j], i[k] = i[k], i[j] }
 func (i intElements) Values() []any {
 	v := make([]any, len(i))
 	for j, e := range i {
@@ -77,6 +86,7 @@ type floatElements []floatElement
 
 func (f floatElements) Len() int           { return len(f) }
 func (f floatElements) Elem(j int) Element { return &f[j] }
+func (f floatElements) Swap(j, k int)      { f[j], f[k] = f[k], f[j] }
 func (f floatElements) Values() []any {
 	v := make([]any, len(f))
 	for j, e := range f {
@@ -110,6 +120,7 @@ type booleanElements []booleanElement
 
 func (b booleanElements) Len() int           { return len(b) }
 func (b booleanElements) Elem(j int) Element { return &b[j] }
+func (b booleanElements) Swap(j, k int)      { b[j], b[k] = b[k], b[j] }
 func (b booleanElements) Values() []any {
 	v := make([]any, len(b))
 	for j, e := range b {
@@ -148,6 +159,7 @@ type stringElements []stringElement
 
 func (s stringElements) Len() int           { return len(s) }
 func (s stringElements) Elem(j int) Element { return &s[j] }
+func (s stringElements) Swap(j, k int)      { s[j], s[k] = s[k], s[j] }
 func (s stringElements) Values() []any {
 	v := make([]any, len(s))
 	for j, e := range s {
@@ -166,28 +178,50 @@ func AsString(e Element) (string, bool) {
 		return "", false
 	}
 	switch v := e.Get().(type) {
-	case string:
-		return v, true
-	case int:
-		return fmt.Sprint(v), true
-	case float64:
-		return fmt.Sprintf("%f", v), true
-	case bool:
-		return fmt.Sprintf("%t", v), true
+	case string, int, float64, bool, time.Time:
+		return FormatValue(v, NumberFormat{}), true
 	default:
 		return "", false
 	}
 }
 
+// datetimeElements is the implementation of the Element interface for datetime types
+type datetimeElements []datetimeElement
+
+func (d datetimeElements) Len() int           { return len(d) }
+func (d datetimeElements) Elem(j int) Element { return &d[j] }
+func (d datetimeElements) Swap(j, k int)      { d[j], d[k] = d[k], d[j] }
+func (d datetimeElements) Values() []any {
+	v := make([]any, len(d))
+	for j, e := range d {
+		if e.IsNA() {
+			v[j] = nil
+		} else {
+			v[j] = e.e
+		}
+	}
+	return v
+}
+
+// AsTime converts an Element to a time.Time, returning false if the conversion is not possible
+func AsTime(e Element) (time.Time, bool) {
+	if e.IsNA() {
+		return time.Time{}, false
+	}
+	v, ok := e.Get().(time.Time)
+	return v, ok
+}
+
 // Type defines the type of the series
 type Type string
 
 const (
-	Int     Type = "int"
-	Float   Type = "float"
-	Boolean Type = "bool"
-	String  Type = "string"
-	Runic   Type = "rune"
+	Int      Type = "int"
+	Float    Type = "float"
+	Boolean  Type = "bool"
+	String   Type = "string"
+	Runic    Type = "rune"
+	Datetime Type = "datetime"
 )
 
 // New creates a new series from a slice of values of type t, and a name
@@ -210,6 +244,8 @@ func NewWithValidity(v any, mask []bool, t Type, name string) Series {
 			s.elements = make(booleanElements, n)
 		case String:
 			s.elements = make(stringElements, n)
+		case Datetime:
+			s.elements = make(datetimeElements, n)
 		case Runic:
 			panic("not implemented")
 		}
@@ -217,13 +253,8 @@ func NewWithValidity(v any, mask []bool, t Type, name string) Series {
 
 	if v == nil {
 		allocMemory(1)
-		s.elements.Elem(0).Set(nil)
-		// Create validity bitset only if element is NA
-		if s.Elem(0).IsNA() {
-			b := NewBitset(1)
-			b.Clear(0)
-			s.valid = b
-		}
+		s.valid = NewBitset(1)
+		s.SetNull(0)
 		return s
 	}
 
@@ -252,45 +283,34 @@ func NewWithValidity(v any, mask []bool, t Type, name string) Series {
 		for i, e := range v_ {
 			s.elements.Elem(i).Set(e)
 		}
+	case []any:
+		l := len(v_)
+		allocMemory(l)
+		for i, e := range v_ {
+			s.elements.Elem(i).Set(e)
+		}
+	case []time.Time:
+		l := len(v_)
+		allocMemory(l)
+		for i, e := range v_ {
+			s.elements.Elem(i).Set(e)
+		}
 	case []rune:
 		panic("not implemented")
 	default:
 		panic(fmt.Sprintf("unsupported type, %T", v_))
 	}
 
-	// apply mask if provided
-	if mask != nil {
-		if len(mask) != s.Len() {
-			panic(fmt.Errorf("validity mask length %v does not match values length %v", len(mask), s.Len()))
-		}
-		b := NewBitset(s.Len())
-		for i := 0; i < s.Len(); i++ {
-			if !mask[i] {
-				// mark element as NA
-				s.Elem(i).Set(nil)
-				b.Clear(i)
-			}
-		}
-		s.valid = b
-		return s
+	if mask != nil && len(mask) != s.Len() {
+		panic(fmt.Errorf("validity mask length %v does not match values length %v", len(mask), s.Len()))
 	}
 
-	// Only create a validity bitset if any element is NA; keep nil for fast-path when all valid
-	anyNA := false
+	// build the validity bitset from the mask if provided, otherwise from the element NA flags
+	s.valid = NewBitset(s.Len())
 	for i := 0; i < s.Len(); i++ {
-		if s.Elem(i).IsNA() {
-			anyNA = true
-			break
-		}
-	}
-	if anyNA {
-		b := NewBitset(s.Len())
-		for i := 0; i < s.Len(); i++ {
-			if s.Elem(i).IsNA() {
-				b.Clear(i)
-			}
+		if (mask != nil && !mask[i]) || s.elements.Elem(i).IsNA() {
+			s.SetNull(i)
 		}
-		s.valid = b
 	}
 
 	return s
@@ -315,20 +335,19 @@ func (s Series) Copy() Series { // unchanged header, kept for edit context
 	case String:
 		elements = make(stringElements, s.elements.Len())
 		copy(elements.(stringElements), s.elements.(stringElements))
+	case Datetime:
+		elements = make(datetimeElements, s.elements.Len())
+		copy(elements.(datetimeElements), s.elements.(datetimeElements))
 	case Runic:
 		panic("not implemented")
 	}
 
-	var valid *Bitset
-	if s.valid != nil {
-		valid = s.valid.Clone()
-	}
-
 	return Series{
 		Name:     name,
 		elements: elements,
-		valid:    valid,
+		valid:    s.valid.Clone(),
 		t:        t,
+		format:   s.format,
 	}
 }
 
@@ -337,21 +356,7 @@ func (s Series) Copy() Series { // unchanged header, kept for edit context
 // FillNA returns a copy of the series with NA values replaced by value.
 func (s Series) FillNA(value any) Series {
 	res := s.Copy()
-	for i := 0; i < res.Len(); i++ {
-		if res.IsNull(i) {
-			res.Elem(i).Set(value)
-			if res.valid == nil {
-				res.valid = NewBitset(res.Len())
-				// set all existing to 1 except NA
-				for j := 0; j < res.Len(); j++ {
-					if !res.Elem(j).IsNA() {
-						res.valid.Set(j)
-					}
-				}
-			}
-			res.valid.Set(i)
-		}
-	}
+	res.MutFillNA(value)
 	return res
 }
 
@@ -360,15 +365,6 @@ func (s *Series) MutFillNA(value any) {
 	for i := 0; i < s.Len(); i++ {
 		if s.IsNull(i) {
 			s.Elem(i).Set(value)
-			if s.valid == nil {
-				s.valid = NewBitset(s.Len())
-				for j := 0; j < s.Len(); j++ {
-					if !s.Elem(j).IsNA() {
-						s.valid.Set(j)
-					}
-				}
-			}
-			s.valid.Set(i)
 		}
 	}
 }
@@ -376,7 +372,7 @@ func (s *Series) MutFillNA(value any) {
 // DropNA returns a new Series with NA values removed.
 func (s Series) DropNA() Series {
 	n := s.Len() - s.CountNulls()
-	res := Series{Name: s.Name, t: s.t}
+	res := Series{Name: s.Name, t: s.t, format: s.format}
 	// allocate
 	switch s.t {
 	case Int:
@@ -387,9 +383,12 @@ func (s Series) DropNA() Series {
 		res.elements = make(booleanElements, n)
 	case String:
 		res.elements = make(stringElements, n)
+	case Datetime:
+		res.elements = make(datetimeElements, n)
 	default:
 		panic("unsupported type")
 	}
+	res.valid = NewBitset(n)
 	idx := 0
 	for i := 0; i < s.Len(); i++ {
 		if s.IsNull(i) {
@@ -407,10 +406,9 @@ func (s Series) CopyWithValidity(copyValues bool) Series {
 	if !copyValues {
 		// zero values but clone validity
 		for i := 0; i < res.Len(); i++ {
-			res.Elem(i).Set(zeroForType(res.t))
-		}
-		if s.valid != nil {
-			res.valid = s.valid.Clone()
+			if res.IsValid(i) {
+				res.Elem(i).Set(zeroForType(res.t))
+			}
 		}
 	}
 	return res
@@ -432,6 +430,8 @@ func zeroForType(t Type) any {
 		return false
 	case String:
 		return ""
+	case Datetime:
+		return time.Time{}
 	default:
 		return nil
 	}
@@ -459,35 +459,20 @@ func (s *Series) Append(v any) {
 		el := stringElement{}
 		el.Set(v)
 		s.elements = append(s.elements.(stringElements), el)
+	case Datetime:
+		el := datetimeElement{}
+		el.Set(v)
+		s.elements = append(s.elements.(datetimeElements), el)
 	case Runic:
 		panic("not implemented")
 	}
 
-	// maintain validity bitset: if s.valid is nil and no NA present, keep nil (fast path).
-	// if any NA present, create or rebuild bitset to reflect current elements.
+	// grow the validity bitset and record the new element
 	if s.valid == nil {
-		// detect if any NA exists now
-		for i := 0; i < s.Len(); i++ {
-			if s.Elem(i).IsNA() {
-				b := NewBitset(s.Len())
-				for j := 0; j < s.Len(); j++ {
-					if s.Elem(j).IsNA() {
-						b.Clear(j)
-					}
-				}
-				s.valid = b
-				break
-			}
-		}
-	} else {
-		// s.valid exists; ensure capacity and set/clear last bit accordingly
-		s.valid.EnsureCapacity(s.Len())
-		if s.Elem(s.Len() - 1).IsNA() {
-			s.valid.Clear(s.Len() - 1)
-		} else {
-			s.valid.Set(s.Len() - 1)
-		}
+		s.valid = NewBitset(s.Len() - 1)
 	}
+	s.valid.EnsureCapacity(s.Len())
+	s.valid.Put(s.Len()-1, !s.elements.Elem(s.Len()-1).IsNA())
 }
 
 // String returns the Stringer implementation of the series
@@ -503,19 +488,80 @@ func (s Series) Val(i int) any {
 	return s.elements.Elem(i).Get()
 }
 
-// Elem returns the element at index i
+// Elem returns the element at index i. Setting a value through the element keeps the
+// validity of the series in step; prefer SetAt, which also reports unsupported values.
 func (s Series) Elem(i int) Element {
-	return s.elements.Elem(i)
+	return cell{Element: s.elements.Elem(i), valid: s.valid, i: i}
+}
+
+// cell is an element of a series that records its nullness in the series' validity bitset.
+type cell struct {
+	Element
+	valid *Bitset
+	i     int
+}
+
+func (c cell) Set(value any) {
+	c.Element.Set(value)
+	c.valid.Put(c.i, !c.Element.IsNA())
+}
+
+func (c cell) IsNA() bool {
+	return !c.valid.Get(c.i)
+}
+
+// SetAt sets the value at index i, converting it to the type of the series as Element.Set
+// does. A nil value, or a NaN or infinite float, makes the value null. It returns an error and
+// leaves the series unchanged if the value cannot be stored in the series.
+func (s Series) SetAt(i int, value any) error {
+	if i < 0 || i >= s.Len() {
+		panic(fmt.Errorf("index %v out of range", i))
+	}
+	if value == nil {
+		s.SetNull(i)
+		return nil
+	}
+	if f, ok := value.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		s.SetNull(i)
+		return nil
+	}
+
+	// element setters mark unsupported values as NA, so try the value on a detached element first
+	probe := newElement(s.t)
+	if probe.Set(value); probe.IsNA() {
+		return fmt.Errorf("cannot set %T value in %v series %v", value, s.t, s.Name)
+	}
+	s.Elem(i).Set(value)
+	return nil
+}
+
+// SetNull makes the value at index i null.
+func (s Series) SetNull(i int) {
+	s.elements.Elem(i).Set(nil)
+	s.valid.Clear(i)
+}
+
+// newElement returns a detached zero element of type t.
+func newElement(t Type) Element {
+	switch t {
+	case Int:
+		return &intElement{}
+	case Float:
+		return &floatElement{}
+	case Boolean:
+		return &booleanElement{}
+	case String:
+		return &stringElement{}
+	case Datetime:
+		return &datetimeElement{}
+	default:
+		panic(fmt.Errorf("type %v not supported", t))
+	}
 }
 
 // HasNa returns true if the series has any NA values
 func (s Series) HasNa() bool {
-	for i := 0; i < s.Len(); i++ {
-		if s.Elem(i).IsNA() {
-			return true
-		}
-	}
-	return false
+	return s.AnyNull()
 }
 
 // Slice returns a copy of the series from index a to index b
@@ -528,7 +574,7 @@ func (s Series) Slice(a, b int) Series {
 		panic(fmt.Errorf("b index %v out of range", b))
 	}
 
-	se := Series{Name: s.Name, t: s.t}
+	se := Series{Name: s.Name, t: s.t, format: s.format}
 	n := b - a
 
 	allocMemory := func(n int) {
@@ -541,6 +587,8 @@ func (s Series) Slice(a, b int) Series {
 			se.elements = make(booleanElements, n)
 		case String:
 			se.elements = make(stringElements, n)
+		case Datetime:
+			se.elements = make(datetimeElements, n)
 		case Runic:
 			panic("not implemented")
 		default:
@@ -549,42 +597,11 @@ func (s Series) Slice(a, b int) Series {
 	}
 	allocMemory(n)
 
+	se.valid = NewBitset(n)
 	for i := a; i < b; i++ {
 		se.Elem(i - a).Set(s.Val(i))
 	}
 
-	// slice validity
-	if s.valid == nil {
-		// if original had no bitset but some elements might be NA flagged at element level
-		anyNA := false
-		for i := a; i < b; i++ {
-			if s.Elem(i).IsNA() {
-				anyNA = true
-				break
-			}
-		}
-		if anyNA {
-			bset := NewBitset(n)
-			for i := range n {
-				if se.Elem(i).IsNA() {
-					bset.Clear(i)
-				}
-			}
-			se.valid = bset
-		}
-	} else {
-		// clone relevant range
-		bset := NewBitset(n)
-		for i := range n {
-			if s.valid.Get(a + i) {
-				bset.Set(i)
-			} else {
-				bset.Clear(i)
-			}
-		}
-		se.valid = bset
-	}
-
 	return se
 }
 
@@ -605,8 +622,23 @@ func (s Series) Sort() {
 	s.Order(idx...)
 }
 
-// SortedIndex returns the indices of the series sorted in ascending order using merge sort
-func (s Series) SortedIndex() []int {
+// SortedIndexOptions defines optional settings for Series.SortedIndex.
+type SortedIndexOptions struct {
+	Descending bool // order values from largest to smallest
+	NullsFirst bool // place nulls before all values instead of after them
+}
+
+// SortedIndex returns the indices of the series in sorted order using a stable merge sort.
+// By default values are ascending and nulls come last.
+func (s Series) SortedIndex(opts ...SortedIndexOptions) []int {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	var o SortedIndexOptions
+	if len(opts) == 1 {
+		o = opts[0]
+	}
+
 	n := s.Len()
 	index := make([]int, n)
 	for i := range n {
@@ -616,33 +648,27 @@ func (s Series) SortedIndex() []int {
 		return index
 	}
 
-	// comparator for two element positions; NULLs are considered greater (sorted to end)
-	less := func(a, b int) bool {
-		// handle nulls
-		if s.IsNull(a) {
-			if s.IsNull(b) {
-				return false
+	// comparator for two element positions; nulls sort to the end unless NullsFirst is set
+	compare := func(a, b int) int {
+		na, nb := s.IsNull(a), s.IsNull(b)
+		if na || nb {
+			r := 0
+			switch {
+			case na && !nb:
+				r = 1
+			case !na && nb:
+				r = -1
 			}
-			return false // a is null, b not -> a > b
-		}
-		if s.IsNull(b) {
-			return true // a not null, b null -> a < b
+			if o.NullsFirst {
+				r = -r
+			}
+			return r
 		}
-		switch s.t {
-		case Int:
-			return s.Val(a).(int) < s.Val(b).(int)
-		case Float:
-			return s.Val(a).(float64) < s.Val(b).(float64)
-		case Boolean:
-			// false < true
-			return !s.Val(a).(bool) && s.Val(b).(bool)
-		case String:
-			return fmt.Sprint(s.Val(a)) < fmt.Sprint(s.Val(b))
-		case Runic:
-			panic("not implemented")
-		default:
-			panic("unsupported type")
+		r := s.compareAt(a, b)
+		if o.Descending {
+			r = -r
 		}
+		return r
 	}
 
 	tmp := make([]int, n)
@@ -656,7 +682,8 @@ func (s Series) SortedIndex() []int {
 		mergeSort(mid, hi)
 		i, j, k := lo, mid, lo
 		for i < mid && j < hi {
-			if less(index[i], index[j]) {
+			// take from the left run on ties to keep the sort stable
+			if compare(index[i], index[j]) <= 0 {
 				tmp[k] = index[i]
 				i++
 			} else {
@@ -684,6 +711,34 @@ func (s Series) SortedIndex() []int {
 	return index
 }
 
+// compareAt orders the non-null values at positions a and b; false sorts before true.
+func (s Series) compareAt(a, b int) int {
+	switch s.t {
+	case Int:
+		return cmp.Compare(s.Val(a).(int), s.Val(b).(int))
+	case Float:
+		return cmp.Compare(s.Val(a).(float64), s.Val(b).(float64))
+	case Boolean:
+		x, y := s.Val(a).(bool), s.Val(b).(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		default:
+			return 1
+		}
+	case String:
+		return cmp.Compare(s.Val(a).(string), s.Val(b).(string))
+	case Datetime:
+		return s.Val(a).(time.Time).Compare(s.Val(b).(time.Time))
+	case Runic:
+		panic("not implemented")
+	default:
+		panic("unsupported type")
+	}
+}
+
 // Order returns the series with the elements ordered according to the positions slice
 func (s Series) Order(positions ...int) Series {
 	if len(positions) != s.Len() {
@@ -699,9 +754,7 @@ func (s Series) Order(positions ...int) Series {
 			continue
 		}
 
-		temp := s.Val(oldPos)
-		s.Elem(oldPos).Set(s.Val(newPos))
-		s.Elem(newPos).Set(temp)
+		s.Swap(oldPos, newPos)
 
 		for i, pos := range newPositions {
 			if pos == newPos {
@@ -715,6 +768,29 @@ func (s Series) Order(positions ...int) Series {
 	return s
 }
 
+// Swap exchanges the values at index i and j in place, nulls included.
+func (s Series) Swap(i, j int) {
+	s.elements.Swap(i, j)
+	vi, vj := s.valid.Get(i), s.valid.Get(j)
+	s.valid.Put(i, vj)
+	s.valid.Put(j, vi)
+}
+
+// Take returns a new series built from the elements at the given positions, in order.
+// Negative positions produce nulls, which is useful for aligning rows that have no counterpart.
+func (s Series) Take(positions []int) Series {
+	res := NewEmptySeries(s.t, len(positions), s.Name)
+	for i, pos := range positions {
+		if pos >= 0 && s.IsValid(pos) {
+			res.Elem(i).Set(s.Val(pos))
+		} else {
+			res.SetNull(i)
+		}
+	}
+	res.format = s.format
+	return res
+}
+
 // Count returns the number of occurrences of the value v in the series
 func (s Series) Count(v any) int {
 	count := 0
@@ -779,10 +855,7 @@ func (s Series) Type() Type {
 
 // IsNull returns true if the element at index i is NA/null.
 func (s Series) IsNull(i int) bool {
-	if s.valid != nil {
-		return !s.valid.Get(i)
-	}
-	return s.Elem(i).IsNA()
+	return !s.valid.Get(i)
 }
 
 // IsValid returns true if the element at index i is not NA/null.
@@ -792,16 +865,10 @@ func (s Series) IsValid(i int) bool {
 
 // CountNulls returns the number of NA/null values in the series.
 func (s Series) CountNulls() int {
-	if s.valid != nil {
-		return s.Len() - s.valid.Count()
-	}
-	count := 0
-	for i := 0; i < s.Len(); i++ {
-		if s.Elem(i).IsNA() {
-			count++
-		}
+	if s.valid == nil {
+		return 0
 	}
-	return count
+	return s.Len() - s.valid.Count()
 }
 
 // AnyNull returns true if any element in the series is null.
@@ -831,11 +898,37 @@ func InferType(v any) Type {
 		return String
 	case rune:
 		return Runic
+	case time.Time:
+		return Datetime
 	default:
 		panic(fmt.Errorf("unsupported type %T", v))
 	}
 }
 
+// CommonType returns the narrowest Type able to hold values of all the given types.
+// Booleans widen to Int and Int widens to Float; any other mix of different types is String.
+func CommonType(types ...Type) Type {
+	if len(types) == 0 {
+		return String
+	}
+	if !slices.ContainsFunc(types, func(t Type) bool { return t != types[0] }) {
+		return types[0]
+	}
+
+	rank := map[Type]int{Boolean: 0, Int: 1, Float: 2}
+	common := types[0]
+	for _, t := range types {
+		rt, ok := rank[t]
+		if !ok {
+			return String
+		}
+		if rt > rank[common] {
+			common = t
+		}
+	}
+	return common
+}
+
 // IsNumeric returns true if the series is of a numeric type (int, float, bool)
 func (s Series) IsNumeric() bool {
 	return s.t == Int || s.t == Float || s.t == Boolean
@@ -244,3 +244,28 @@ func TestSeriesNullHelpers(t *testing.T) {
 	assert.Equal(t, d.Len(), 2)
 	assert.Equal(t, d.Val(0), 2)
 }
+
+func TestCommonTypeAndNewFromAny(t *testing.T) {
+	assert.Equal(t, CommonType(Int, Float), Float)
+	assert.Equal(t, CommonType(Boolean, Int), Int)
+	assert.Equal(t, CommonType(Int, String), String)
+	assert.Equal(t, CommonType(Boolean), Boolean)
+
+	s := New([]any{1, nil, 2.5}, Float, "A")
+	assert.Equal(t, s.Val(0), 1.0)
+	assert.Equal(t, s.IsNull(1), true)
+	assert.Equal(t, s.Val(2), 2.5)
+}
+
+func TestSeries_Take(t *testing.T) {
+	s := New([]string{"a", "b", "c"}, String, "S")
+	s.Elem(2).Set(nil)
+
+	tk := s.Take([]int{1, -1, 2, 0})
+	assert.Equal(t, tk.Len(), 4)
+	assert.Equal(t, tk.Val(0), "b")
+	assert.Equal(t, tk.IsNull(1), true)
+	assert.Equal(t, tk.IsNull(2), true)
+	assert.Equal(t, tk.Val(3), "a")
+	assert.Equal(t, tk.CountNulls(), 2)
+}
@@ -0,0 +1,221 @@
+package series
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringMethods provides vectorized string operations on a String series, see Series.Str.
+// Every operation leaves null values null and names its results after the series.
+type StringMethods struct {
+	s Series
+}
+
+// Str returns the string operations of a String series.
+func (s Series) Str() StringMethods {
+	if s.t != String {
+		panic(fmt.Errorf("string operations are only supported for string types, got %v", s.t))
+	}
+	return StringMethods{s: s}
+}
+
+// PadSide selects where StringMethods.Pad adds fill characters.
+type PadSide int
+
+const (
+	PadLeft  PadSide = iota // right-align values
+	PadRight                // left-align values
+	PadBoth                 // centre values, favouring the right for odd padding
+)
+
+// Lower returns the values converted to lower case.
+func (m StringMethods) Lower() Series {
+	return m.apply(String, func(v string) any { return strings.ToLower(v) })
+}
+
+// Upper returns the values converted to upper case.
+func (m StringMethods) Upper() Series {
+	return m.apply(String, func(v string) any { return strings.ToUpper(v) })
+}
+
+// Trim returns the values with leading and trailing white space removed.
+func (m StringMethods) Trim() Series {
+	return m.apply(String, func(v string) any { return strings.TrimSpace(v) })
+}
+
+// Len returns the number of characters in each value as an Int series.
+func (m StringMethods) Len() Series {
+	return m.apply(Int, func(v string) any { return utf8.RuneCountInString(v) })
+}
+
+// Contains reports whether each value contains substr.
+func (m StringMethods) Contains(substr string) Series {
+	return m.apply(Boolean, func(v string) any { return strings.Contains(v, substr) })
+}
+
+// HasPrefix reports whether each value begins with prefix.
+func (m StringMethods) HasPrefix(prefix string) Series {
+	return m.apply(Boolean, func(v string) any { return strings.HasPrefix(v, prefix) })
+}
+
+// HasSuffix reports whether each value ends with suffix.
+func (m StringMethods) HasSuffix(suffix string) Series {
+	return m.apply(Boolean, func(v string) any { return strings.HasSuffix(v, suffix) })
+}
+
+// Replace returns the values with every occurrence of old replaced by new.
+func (m StringMethods) Replace(old, new string) Series {
+	return m.apply(String, func(v string) any { return strings.ReplaceAll(v, old, new) })
+}
+
+// Slice returns the characters of each value from start up to end. Negative positions count
+// from the end of the value, and positions beyond either end are clamped.
+func (m StringMethods) Slice(start, end int) Series {
+	return m.apply(String, func(v string) any {
+		r := []rune(v)
+		clamp := func(i int) int {
+			if i < 0 {
+				i += len(r)
+			}
+			return min(max(i, 0), len(r))
+		}
+		lo, hi := clamp(start), clamp(end)
+		if lo >= hi {
+			return ""
+		}
+		return string(r[lo:hi])
+	})
+}
+
+// Pad returns the values padded with fill to at least width characters on the given side.
+func (m StringMethods) Pad(width int, side PadSide, fill rune) Series {
+	return m.apply(String, func(v string) any {
+		n := width - utf8.RuneCountInString(v)
+		if n <= 0 {
+			return v
+		}
+		switch side {
+		case PadLeft:
+			return strings.Repeat(string(fill), n) + v
+		case PadRight:
+			return v + strings.Repeat(string(fill), n)
+		default:
+			return strings.Repeat(string(fill), n/2) + v + strings.Repeat(string(fill), n-n/2)
+		}
+	})
+}
+
+// Match reports whether each value contains a match of the regular expression pattern.
+// Anchor the pattern with ^ and $ to match whole values.
+func (m StringMethods) Match(pattern string) Series {
+	re := regexp.MustCompile(pattern)
+	return m.apply(Boolean, func(v string) any { return re.MatchString(v) })
+}
+
+// Extract returns one String series per capture group of pattern, holding the group's text in
+// the first match of each value. Series are named after named groups, or after the series and
+// the group number otherwise. Values without a match, and groups that take no part in a match,
+// are null.
+func (m StringMethods) Extract(pattern string) []Series {
+	re := regexp.MustCompile(pattern)
+	if re.NumSubexp() == 0 {
+		panic(fmt.Errorf("pattern %q has no capture groups", pattern))
+	}
+
+	out := make([][]any, re.NumSubexp())
+	for g := range out {
+		out[g] = make([]any, m.s.Len())
+	}
+	for i := range m.s.Len() {
+		if m.s.IsNull(i) {
+			continue
+		}
+		v := m.s.Val(i).(string)
+		match := re.FindStringSubmatchIndex(v)
+		for g := range out {
+			// groups that take no part in the match have negative offsets
+			if lo, hi := 2*(g+1), 2*(g+1)+1; match != nil && match[lo] >= 0 {
+				out[g][i] = v[match[lo]:match[hi]]
+			}
+		}
+	}
+
+	res := make([]Series, len(out))
+	for g, vals := range out {
+		name := re.SubexpNames()[g+1]
+		if name == "" {
+			name = fmt.Sprintf("%v_%d", m.s.Name, g+1)
+		}
+		res[g] = New(vals, String, name)
+	}
+	return res
+}
+
+// Split splits each value around sep into at most n parts and returns one String series per
+// part, named after the series and the part number from 0. With n <= 0 every part is kept and
+// there are as many series as the longest split. Missing parts are null.
+func (m StringMethods) Split(sep string, n int) []Series {
+	parts := make([][]string, m.s.Len())
+	width := n
+	for i := range parts {
+		if m.s.IsNull(i) {
+			continue
+		}
+		if n > 0 {
+			parts[i] = strings.SplitN(m.s.Val(i).(string), sep, n)
+		} else {
+			parts[i] = strings.Split(m.s.Val(i).(string), sep)
+			width = max(width, len(parts[i]))
+		}
+	}
+
+	res := make([]Series, width)
+	for k := range res {
+		vals := make([]any, len(parts))
+		for i, p := range parts {
+			if k < len(p) {
+				vals[i] = p[k]
+			}
+		}
+		res[k] = New(vals, String, fmt.Sprintf("%v_%d", m.s.Name, k))
+	}
+	return res
+}
+
+// Cat concatenates the values element-wise with those of others, joined by sep. Values of
+// others are printed with their own format; a null in any input gives a null result.
+func (m StringMethods) Cat(sep string, others ...Series) Series {
+	for _, o := range others {
+		if o.Len() != m.s.Len() {
+			panic(fmt.Errorf("series %v has length %v, expected %v", o.Name, o.Len(), m.s.Len()))
+		}
+	}
+
+	vals := make([]any, m.s.Len())
+	parts := make([]string, len(others)+1)
+	for i := range vals {
+		if m.s.IsNull(i) || slices.ContainsFunc(others, func(o Series) bool { return o.IsNull(i) }) {
+			continue
+		}
+		parts[0] = m.s.Val(i).(string)
+		for k, o := range others {
+			parts[k+1] = o.FormatVal(i)
+		}
+		vals[i] = strings.Join(parts, sep)
+	}
+	return New(vals, String, m.s.Name)
+}
+
+// apply maps the non-null values of the series with fn into a series of type t.
+func (m StringMethods) apply(t Type, fn func(string) any) Series {
+	vals := make([]any, m.s.Len())
+	for i := range vals {
+		if m.s.IsValid(i) {
+			vals[i] = fn(m.s.Val(i).(string))
+		}
+	}
+	return New(vals, t, m.s.Name)
+}
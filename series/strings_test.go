@@ -0,0 +1,65 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+func TestStringMethods_Map(t *testing.T) {
+	s := New([]any{" Go ", nil, "héllo"}, String, "s")
+
+	assert.Equal(t, s.Str().Lower().String(), "{s [ go  <nil> héllo] string}")
+	assert.Equal(t, s.Str().Upper().String(), "{s [ GO  <nil> HÉLLO] string}")
+	assert.Equal(t, s.Str().Trim().String(), "{s [Go <nil> héllo] string}")
+	assert.Equal(t, s.Str().Len().String(), "{s [4 <nil> 5] int}")
+	assert.Equal(t, s.Str().Contains("ll").String(), "{s [false <nil> true] bool}")
+	assert.Equal(t, s.Str().HasPrefix(" G").String(), "{s [true <nil> false] bool}")
+	assert.Equal(t, s.Str().HasSuffix("o").String(), "{s [false <nil> true] bool}")
+	assert.Equal(t, s.Str().Replace("l", "L").String(), "{s [ Go  <nil> héLLo] string}")
+	assert.Equal(t, s.Str().Slice(1, -1).String(), "{s [Go <nil> éll] string}")
+	assert.Equal(t, s.Str().Slice(3, 99).String(), "{s [  <nil> lo] string}")
+
+	p := New([]string{"ab", "abcd"}, String, "p")
+	assert.Equal(t, p.Str().Pad(4, PadLeft, '.').String(), "{p [..ab abcd] string}")
+	assert.Equal(t, p.Str().Pad(4, PadRight, '.').String(), "{p [ab.. abcd] string}")
+	assert.Equal(t, p.Str().Pad(5, PadBoth, '*').String(), "{p [*ab** abcd*] string}")
+
+	assert.Panic(t, func() { New([]int{1}, Int, "i").Str() })
+}
+
+func TestStringMethods_Regexp(t *testing.T) {
+	s := New([]any{"a-12", "b-7", nil, "none"}, String, "code")
+
+	assert.Equal(t, s.Str().Match(`^[a-z]-\d+$`).String(), "{code [true true <nil> false] bool}")
+
+	parts := s.Str().Extract(`(?P<letter>[a-z])-(\d+)`)
+	assert.Equal(t, len(parts), 2)
+	assert.Equal(t, parts[0].String(), "{letter [a b <nil> <nil>] string}")
+	assert.Equal(t, parts[1].String(), "{code_2 [12 7 <nil> <nil>] string}")
+
+	assert.Panic(t, func() { s.Str().Extract(`[a-z]`) })
+
+	// an optional group that does not take part is null, an empty one that matched is not
+	opt := New([]string{"ab", "a", "a:"}, String, "o").Str().Extract(`a(b)?(:?)`)
+	assert.Equal(t, opt[0].String(), "{o_1 [b <nil> <nil>] string}")
+	assert.Equal(t, opt[1].IsValid(1), true)
+	assert.Equal(t, opt[1].Val(1), "")
+}
+
+func TestStringMethods_SplitCat(t *testing.T) {
+	s := New([]any{"a,b,c", "d", nil}, String, "s")
+
+	all := s.Str().Split(",", 0)
+	assert.Equal(t, len(all), 3)
+	assert.Equal(t, all[0].String(), "{s_0 [a d <nil>] string}")
+	assert.Equal(t, all[2].String(), "{s_2 [c <nil> <nil>] string}")
+
+	two := s.Str().Split(",", 2)
+	assert.Equal(t, len(two), 2)
+	assert.Equal(t, two[1].String(), "{s_1 [b,c <nil> <nil>] string}")
+
+	n := New([]any{1.5, nil, 3.0}, Float, "n")
+	assert.Equal(t, s.Str().Cat("=", n).String(), "{s [a,b,c=1.5 <nil> <nil>] string}")
+	assert.Equal(t, s.Str().Cat("").String(), "{s [a,b,c d <nil>] string}")
+}
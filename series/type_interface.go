@@ -3,6 +3,7 @@ package series
 import (
 	"fmt"
 	"math"
+	"time"
 )
 
 type intElement struct {
@@ -162,11 +163,13 @@ func (s *stringElement) Set(value any) {
 	case bool:
 		s.e = fmt.Sprintf("%t", v)
 	case float64:
-		s.e = fmt.Sprintf("%f", v)
+		s.e = FormatValue(v, NumberFormat{})
 	case string:
 		s.e = v
 	case rune:
 		s.e = string(v)
+	case time.Time:
+		s.e = v.Format(time.RFC3339Nano)
 	default:
 		s.nan = true
 		return
@@ -188,3 +191,39 @@ func (s stringElement) Type() Type {
 func (s stringElement) IsNumeric() bool {
 	return false
 }
+
+type datetimeElement struct {
+	e   time.Time
+	nan bool
+}
+
+// force implementation of Element interface
+var _ Element = (*datetimeElement)(nil)
+
+func (d *datetimeElement) Set(value any) {
+	d.nan = false
+
+	switch v := value.(type) {
+	case time.Time:
+		d.e = v
+	default:
+		d.nan = true
+		return
+	}
+}
+
+func (d datetimeElement) Get() any {
+	return d.e
+}
+
+func (d datetimeElement) IsNA() bool {
+	return d.nan
+}
+
+func (d datetimeElement) Type() Type {
+	return Datetime
+}
+
+func (d datetimeElement) IsNumeric() bool {
+	return false
+}
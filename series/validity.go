@@ -86,6 +86,15 @@ func (b *Bitset) Clear(i int) {
 	b.words[word] &^= 1 << bit
 }
 
+// Put marks index i as valid if ok is true and as null otherwise. Panics on out of range.
+func (b *Bitset) Put(i int, ok bool) {
+	if ok {
+		b.Set(i)
+	} else {
+		b.Clear(i)
+	}
+}
+
 // Get returns true if index i is valid (not null).
 func (b *Bitset) Get(i int) bool {
 	if b == nil {
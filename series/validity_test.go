@@ -1,6 +1,13 @@
 package series
 
-import "testing"
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
 
 func TestBitsetBasic(t *testing.T) {
 	b := NewBitset(130)
@@ -27,3 +34,145 @@ func TestBitsetBasic(t *testing.T) {
 		t.Fatalf("expected 130 indices, got %d", len(idx))
 	}
 }
+
+func TestSeries_SetAtAndSetNull(t *testing.T) {
+	s := New([]int{1, 2, 3}, Int, "A")
+
+	assert.Equal(t, s.SetAt(0, 10), nil)
+	assert.Equal(t, s.Val(0), 10)
+
+	assert.Equal(t, s.SetAt(1, nil), nil)
+	assert.Equal(t, s.IsNull(1), true)
+	assert.Equal(t, s.Elem(1).IsNA(), true)
+	assert.Equal(t, s.SetAt(1, math.NaN()), nil)
+	assert.Equal(t, s.IsNull(1), true)
+	assert.Equal(t, s.SetAt(1, 5), nil)
+	assert.Equal(t, s.IsValid(1), true)
+
+	// unsupported values are rejected and leave the value untouched
+	assert.NotEqual(t, s.SetAt(2, "x"), nil)
+	assert.Equal(t, s.Val(2), 3)
+	assert.Equal(t, s.CountNulls(), 0)
+
+	s.SetNull(2)
+	assert.Equal(t, s.IsNull(2), true)
+	assert.Equal(t, s.CountNulls(), 1)
+	assert.Panic(t, func() { _ = s.SetAt(3, 1) })
+}
+
+// randomValues returns n values for a series of type t, about a quarter of them nil.
+func randomValues(r *rand.Rand, t Type, n int) []any {
+	vals := make([]any, n)
+	for i := range vals {
+		if r.IntN(4) == 0 {
+			continue
+		}
+		switch t {
+		case Int:
+			vals[i] = r.IntN(10)
+		case Float:
+			vals[i] = float64(r.IntN(10)) / 2
+		case String:
+			vals[i] = string(rune('a' + r.IntN(10)))
+		case Boolean:
+			vals[i] = r.IntN(2) == 0
+		}
+	}
+	return vals
+}
+
+// assertValues checks that s holds want, and that every view of its nulls agrees.
+func assertValues(t *testing.T, s Series, want []any) {
+	t.Helper()
+	assert.Equal(t, s.Len(), len(want))
+	nulls := 0
+	for i, w := range want {
+		assert.Equal(t, s.Val(i), w)
+		assert.Equal(t, s.IsNull(i), w == nil)
+		assert.Equal(t, s.Elem(i).IsNA(), w == nil)
+		assert.Equal(t, s.elements.Elem(i).IsNA(), w == nil)
+		if w == nil {
+			nulls++
+		}
+	}
+	assert.Equal(t, s.CountNulls(), nulls)
+	assert.Equal(t, s.Copy().CountNulls(), nulls)
+	assert.Equal(t, s.DropNA().Len(), len(want)-nulls)
+}
+
+var nullableTypes = []Type{Int, Float, String, Boolean}
+
+func TestValidity_Sort(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		typ := nullableTypes[r.IntN(len(nullableTypes))]
+		vals := randomValues(r, typ, r.IntN(20))
+		s := New(vals, typ, "S")
+
+		want := slices.Clone(vals)
+		slices.SortStableFunc(want, func(a, b any) int {
+			switch {
+			case a == nil && b == nil:
+				return 0
+			case a == nil:
+				return 1
+			case b == nil:
+				return -1
+			}
+			return New([]any{a, b}, typ, "").compareAt(0, 1)
+		})
+
+		s.Sort()
+		assertValues(t, s, want)
+	}
+}
+
+func TestValidity_Order(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 200 {
+		typ := nullableTypes[r.IntN(len(nullableTypes))]
+		vals := randomValues(r, typ, r.IntN(20))
+		s := New(vals, typ, "S")
+
+		positions := r.Perm(len(vals))
+		want := make([]any, len(vals))
+		for i, p := range positions {
+			want[i] = vals[p]
+		}
+
+		s.Order(positions...)
+		assertValues(t, s, want)
+	}
+}
+
+func TestValidity_Swap(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 200 {
+		typ := nullableTypes[r.IntN(len(nullableTypes))]
+		want := randomValues(r, typ, 1+r.IntN(20))
+		s := New(want, typ, "S")
+
+		for range 10 {
+			i, j := r.IntN(len(want)), r.IntN(len(want))
+			want[i], want[j] = want[j], want[i]
+			s.Swap(i, j)
+		}
+		assertValues(t, s, want)
+	}
+}
+
+func TestValidity_Append(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 200 {
+		typ := nullableTypes[r.IntN(len(nullableTypes))]
+		want := randomValues(r, typ, r.IntN(5))
+		s := New(want, typ, "S")
+
+		for _, v := range randomValues(r, typ, r.IntN(100)) {
+			want = append(want, v)
+			s.Append(v)
+		}
+		assertValues(t, s, want)
+		assertValues(t, s.Slice(len(want)/2, len(want)), want[len(want)/2:])
+	}
+}
@@ -0,0 +1,346 @@
+package series
+
+import (
+	"fmt"
+	"math"
+)
+
+// RollingOptions defines optional settings for Series.Rolling.
+type RollingOptions struct {
+	MinPeriods int  // non-null values required for a result; defaults to the window size
+	Center     bool // label each result at the centre of its window rather than its end
+}
+
+// ExpandingOptions defines optional settings for Series.Expanding.
+type ExpandingOptions struct {
+	MinPeriods int // non-null values required for a result; defaults to 1
+}
+
+// Rolling computes statistics over a moving window of a numeric series. Nulls inside a window are
+// skipped, and a window with fewer than MinPeriods non-null values yields null. Results are Float
+// series named after the source series.
+type Rolling struct {
+	s          Series
+	window     int
+	minPeriods int
+	center     bool
+	expanding  bool
+}
+
+// Rolling returns a moving window of the given size over a numeric series.
+func (s Series) Rolling(window int, opts ...RollingOptions) Rolling {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	if window < 1 {
+		panic(fmt.Errorf("window must be at least 1, got %v", window))
+	}
+	s.mustBeNumeric("rolling window")
+
+	r := Rolling{s: s, window: window, minPeriods: window}
+	if len(opts) == 1 {
+		if opts[0].MinPeriods > window {
+			panic(fmt.Errorf("min periods %v exceeds window %v", opts[0].MinPeriods, window))
+		}
+		if opts[0].MinPeriods > 0 {
+			r.minPeriods = opts[0].MinPeriods
+		}
+		r.center = opts[0].Center
+	}
+	return r
+}
+
+// Expanding returns a window over a numeric series that grows from the first element to the
+// current one.
+func (s Series) Expanding(opts ...ExpandingOptions) Rolling {
+	if len(opts) > 1 {
+		panic("only one options struct allowed")
+	}
+	s.mustBeNumeric("expanding window")
+
+	r := Rolling{s: s, window: s.Len(), minPeriods: 1, expanding: true}
+	if len(opts) == 1 && opts[0].MinPeriods > 0 {
+		r.minPeriods = opts[0].MinPeriods
+	}
+	return r
+}
+
+// Sum returns the sum of each window.
+func (r Rolling) Sum() Series {
+	return sweep(r, &sumAccumulator{}, func(a *sumAccumulator, n int) float64 { return a.sum })
+}
+
+// Mean returns the mean of each window.
+func (r Rolling) Mean() Series {
+	return sweep(r, &sumAccumulator{}, func(a *sumAccumulator, n int) float64 { return a.sum / float64(n) })
+}
+
+// Std returns the sample standard deviation of each window; windows with a single value are null.
+func (r Rolling) Std() Series {
+	return sweep(r, &momentAccumulator{}, func(a *momentAccumulator, n int) float64 {
+		if n < 2 {
+			return math.NaN()
+		}
+		return math.Sqrt(max(a.m2, 0) / float64(n-1))
+	})
+}
+
+// Min returns the smallest value of each window.
+func (r Rolling) Min() Series {
+	return sweep(r, &extremeAccumulator{less: func(a, b float64) bool { return a < b }},
+		func(a *extremeAccumulator, n int) float64 { return a.value() })
+}
+
+// Max returns the largest value of each window.
+func (r Rolling) Max() Series {
+	return sweep(r, &extremeAccumulator{less: func(a, b float64) bool { return a > b }},
+		func(a *extremeAccumulator, n int) float64 { return a.value() })
+}
+
+// Apply calls fn with the non-null values of each window and collects the results.
+// Unlike the built-in statistics it costs O(window) per element.
+func (r Rolling) Apply(fn func([]float64) float64) Series {
+	vals, valid := r.s.floats()
+	out := make([]any, len(vals))
+	var window []float64
+	for i := range vals {
+		lo, hi := r.bounds(i)
+		window = window[:0]
+		for j := lo; j < hi; j++ {
+			if valid[j] {
+				window = append(window, vals[j])
+			}
+		}
+		if len(window) >= r.minPeriods {
+			out[i] = fn(window)
+		}
+	}
+	return New(out, Float, r.s.Name)
+}
+
+// bounds returns the half-open range of positions in the window labelled at position i.
+func (r Rolling) bounds(i int) (int, int) {
+	if r.expanding {
+		return 0, i + 1
+	}
+	lo := i - r.window + 1
+	if r.center {
+		lo = i - r.window/2
+	}
+	return max(lo, 0), min(lo+r.window, r.s.Len())
+}
+
+// accumulator maintains a statistic over the values entering and leaving a sliding window.
+// Values leave in the same order they entered.
+type accumulator interface {
+	add(i int, v float64)
+	remove(i int, v float64)
+}
+
+// sweep slides the window across the series in O(n), feeding non-null values to acc and reading
+// the statistic with value. NaN results are stored as nulls.
+func sweep[A accumulator](r Rolling, acc A, value func(acc A, n int) float64) Series {
+	vals, valid := r.s.floats()
+	out := make([]any, len(vals))
+	lo, hi, n := 0, 0, 0
+	for i := range vals {
+		a, b := r.bounds(i)
+		for ; hi < b; hi++ {
+			if valid[hi] {
+				acc.add(hi, vals[hi])
+				n++
+			}
+		}
+		for ; lo < a; lo++ {
+			if valid[lo] {
+				acc.remove(lo, vals[lo])
+				n--
+			}
+		}
+		if n > 0 && n >= r.minPeriods {
+			out[i] = value(acc, n)
+		}
+	}
+	return New(out, Float, r.s.Name)
+}
+
+// sumAccumulator keeps a running sum.
+type sumAccumulator struct{ sum float64 }
+
+func (a *sumAccumulator) add(_ int, v float64)    { a.sum += v }
+func (a *sumAccumulator) remove(_ int, v float64) { a.sum -= v }
+
+// momentAccumulator keeps a running mean and sum of squared deviations (Welford's algorithm).
+type momentAccumulator struct {
+	n        int
+	mean, m2 float64
+}
+
+func (a *momentAccumulator) add(_ int, v float64) {
+	a.n++
+	d := v - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (v - a.mean)
+}
+
+func (a *momentAccumulator) remove(_ int, v float64) {
+	a.n--
+	if a.n == 0 {
+		a.mean, a.m2 = 0, 0
+		return
+	}
+	d := v - a.mean
+	a.mean -= d / float64(a.n)
+	a.m2 -= d * (v - a.mean)
+}
+
+// extremeAccumulator keeps a monotonic deque of positions whose front is the window's extreme.
+type extremeAccumulator struct {
+	less  func(a, b float64) bool
+	pos   []int
+	vals  []float64
+	front int
+}
+
+func (a *extremeAccumulator) add(i int, v float64) {
+	for len(a.pos) > a.front && !a.less(a.vals[len(a.vals)-1], v) {
+		a.pos = a.pos[:len(a.pos)-1]
+		a.vals = a.vals[:len(a.vals)-1]
+	}
+	a.pos = append(a.pos, i)
+	a.vals = append(a.vals, v)
+}
+
+func (a *extremeAccumulator) remove(i int, _ float64) {
+	if a.front < len(a.pos) && a.pos[a.front] == i {
+		a.front++
+	}
+}
+
+func (a *extremeAccumulator) value() float64 {
+	return a.vals[a.front]
+}
+
+// EWMOptions selects the decay of Series.EWM. Exactly one of Alpha, Span or HalfLife must be set.
+type EWMOptions struct {
+	Alpha      float64 // smoothing factor, 0 < Alpha <= 1
+	Span       float64 // decay by span, Alpha = 2 / (Span + 1), Span >= 1
+	HalfLife   float64 // decay by half-life, Alpha = 1 - exp(-ln 2 / HalfLife), HalfLife > 0
+	MinPeriods int     // non-null values required for a result; defaults to 1
+}
+
+// EWM computes exponentially weighted statistics of a numeric series. Weights are normalised over
+// the observations seen so far and decay with each position, including null ones; null inputs
+// yield null results.
+type EWM struct {
+	s          Series
+	alpha      float64
+	minPeriods int
+}
+
+// EWM returns exponentially weighted statistics of a numeric series.
+func (s Series) EWM(opts EWMOptions) EWM {
+	s.mustBeNumeric("exponentially weighted window")
+
+	set := 0
+	var alpha float64
+	if opts.Alpha != 0 {
+		set++
+		alpha = opts.Alpha
+	}
+	if opts.Span != 0 {
+		set++
+		if opts.Span < 1 {
+			panic(fmt.Errorf("span must be at least 1, got %v", opts.Span))
+		}
+		alpha = 2 / (opts.Span + 1)
+	}
+	if opts.HalfLife != 0 {
+		set++
+		if opts.HalfLife < 0 {
+			panic(fmt.Errorf("half-life must be positive, got %v", opts.HalfLife))
+		}
+		alpha = 1 - math.Exp(-math.Ln2/opts.HalfLife)
+	}
+	if set != 1 {
+		panic("exactly one of Alpha, Span or HalfLife must be set")
+	}
+	if alpha <= 0 || alpha > 1 {
+		panic(fmt.Errorf("alpha must be in (0, 1], got %v", alpha))
+	}
+
+	return EWM{s: s, alpha: alpha, minPeriods: max(opts.MinPeriods, 1)}
+}
+
+// Mean returns the exponentially weighted mean.
+func (e EWM) Mean() Series {
+	return e.sweep(func(w, _, sum, _ float64) float64 { return sum / w })
+}
+
+// Var returns the unbiased exponentially weighted variance; it is null until two values are seen.
+func (e EWM) Var() Series {
+	return e.sweep(func(w, w2, sum, sum2 float64) float64 {
+		if w*w <= w2 {
+			return math.NaN()
+		}
+		mean := sum / w
+		biased := max(sum2/w-mean*mean, 0)
+		return biased * w * w / (w*w - w2)
+	})
+}
+
+// sweep accumulates the decayed weight, squared weight, weighted sum and weighted sum of squares
+// in one pass and derives each result from them. NaN results are stored as nulls.
+func (e EWM) sweep(value func(w, w2, sum, sum2 float64) float64) Series {
+	vals, valid := e.s.floats()
+	out := make([]any, len(vals))
+	decay := 1 - e.alpha
+	var w, w2, sum, sum2 float64
+	n := 0
+	for i, v := range vals {
+		w, w2, sum, sum2 = w*decay, w2*decay*decay, sum*decay, sum2*decay
+		if !valid[i] {
+			continue
+		}
+		w, w2, sum, sum2 = w+1, w2+1, sum+v, sum2+v*v
+		n++
+		if n >= e.minPeriods {
+			out[i] = value(w, w2, sum, sum2)
+		}
+	}
+	return New(out, Float, e.s.Name)
+}
+
+// mustBeNumeric panics if s is not numeric, naming the operation that required it.
+func (s Series) mustBeNumeric(op string) {
+	if !s.IsNumeric() {
+		panic(fmt.Errorf("%v is only supported for numeric types, got %v", op, s.t))
+	}
+}
+
+// floats returns the values of a numeric series as float64 along with their validity.
+func (s Series) floats() ([]float64, []bool) {
+	vals := make([]float64, s.Len())
+	valid := make([]bool, s.Len())
+	for i := range vals {
+		if s.IsValid(i) {
+			vals[i], valid[i] = numericValue(s.Val(i)), true
+		}
+	}
+	return vals, valid
+}
+
+// numericValue converts an int, float64 or bool value to a float64.
+func numericValue(v any) float64 {
+	switch x := v.(type) {
+	case int:
+		return float64(x)
+	case float64:
+		return x
+	case bool:
+		if x {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package series
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+)
+
+// assertFloats checks s against expected values, where nil expects a null.
+func assertFloats(t *testing.T, s Series, expected []any) {
+	t.Helper()
+	assert.Equal(t, s.Len(), len(expected))
+	for i, e := range expected {
+		if e == nil {
+			assert.Equal(t, s.IsNull(i), true)
+			continue
+		}
+		assert.IsClose(t, s.Val(i).(float64), e.(float64), 1e-9)
+	}
+}
+
+func TestSeries_Rolling(t *testing.T) {
+	s := New([]any{1, 2, nil, 4, 5}, Int, "x")
+	assertFloats(t, s.Rolling(2).Sum(), []any{nil, 3.0, nil, nil, 9.0})
+	assertFloats(t, s.Rolling(2, RollingOptions{MinPeriods: 1}).Sum(), []any{1.0, 3.0, 2.0, 4.0, 9.0})
+	assertFloats(t, s.Rolling(3, RollingOptions{MinPeriods: 1, Center: true}).Mean(), []any{1.5, 1.5, 3.0, 4.5, 4.5})
+	assert.Equal(t, s.Rolling(2).Sum().Name, "x")
+
+	m := New([]int{3, 1, 4, 1, 5, 9, 2}, Int, "m")
+	assertFloats(t, m.Rolling(3).Min(), []any{nil, nil, 1.0, 1.0, 1.0, 1.0, 2.0})
+	assertFloats(t, m.Rolling(3).Max(), []any{nil, nil, 4.0, 4.0, 5.0, 9.0, 9.0})
+
+	std := New([]float64{1, 2, 3, 4}, Float, "s").Rolling(3, RollingOptions{MinPeriods: 1}).Std()
+	assertFloats(t, std, []any{nil, 0.7071067811865476, 1.0, 1.0})
+
+	product := New([]int{1, 2, 3}, Int, "p").Rolling(2, RollingOptions{MinPeriods: 1}).Apply(func(vs []float64) float64 {
+		p := 1.0
+		for _, v := range vs {
+			p *= v
+		}
+		return p
+	})
+	assertFloats(t, product, []any{1.0, 2.0, 6.0})
+
+	assert.Panic(t, func() { New([]string{"a"}, String, "s").Rolling(1) })
+	assert.Panic(t, func() { s.Rolling(2, RollingOptions{MinPeriods: 3}) })
+}
+
+func TestSeries_Expanding(t *testing.T) {
+	assertFloats(t, New([]int{3, 1, 4}, Int, "x").Expanding().Max(), []any{3.0, 3.0, 4.0})
+	assertFloats(t, New([]any{1, nil, 3}, Int, "x").Expanding().Mean(), []any{1.0, 1.0, 2.0})
+	assertFloats(t, New([]int{1, 2, 3}, Int, "x").Expanding(ExpandingOptions{MinPeriods: 2}).Sum(), []any{nil, 3.0, 6.0})
+}
+
+func TestSeries_EWM(t *testing.T) {
+	s := New([]int{1, 2, 3}, Int, "x")
+	assertFloats(t, s.EWM(EWMOptions{Alpha: 0.5}).Mean(), []any{1.0, 5.0 / 3, 4.25 / 1.75})
+	assertFloats(t, s.EWM(EWMOptions{Span: 3}).Mean(), []any{1.0, 5.0 / 3, 4.25 / 1.75})
+	assertFloats(t, s.EWM(EWMOptions{HalfLife: 1}).Var(), []any{nil, 0.5, 0.9285714285714286})
+
+	withNull := New([]any{1, nil, 3}, Int, "x")
+	assertFloats(t, withNull.EWM(EWMOptions{Alpha: 0.5}).Mean(), []any{1.0, nil, 2.6})
+
+	assert.Panic(t, func() { s.EWM(EWMOptions{}) })
+	assert.Panic(t, func() { s.EWM(EWMOptions{Alpha: 0.5, Span: 3}) })
+}
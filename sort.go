@@ -0,0 +1,115 @@
+package golumn
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/chriso345/golumn/internal/topn"
+	"github.com/chriso345/golumn/series"
+)
+
+// compareValues orders two cell values: numbers compare numerically (int and float64 together),
+// false sorts before true, strings compare lexically, datetimes chronologically and nulls sort last.
+func compareValues(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	switch x := a.(type) {
+	case int:
+		switch y := b.(type) {
+		case int:
+			return cmp.Compare(x, y)
+		case float64:
+			return cmp.Compare(float64(x), y)
+		}
+	case float64:
+		switch y := b.(type) {
+		case int:
+			return cmp.Compare(x, float64(y))
+		case float64:
+			return cmp.Compare(x, y)
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			default:
+				return 1
+			}
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return cmp.Compare(x, y)
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+
+	// values of different kinds fall back to their printed form
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// compareRows compares rows i and j across cols in order, returning the first non-zero result.
+func compareRows(cols []series.Series, i, j int) int {
+	for _, c := range cols {
+		if r := compareValues(c.Val(i), c.Val(j)); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+// sortedPositions returns row positions ordered by cols using a stable sort, so ties keep their
+// original order.
+func sortedPositions(cols []series.Series, n int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = i
+	}
+	slices.SortStableFunc(positions, func(i, j int) int {
+		return compareRows(cols, i, j)
+	})
+	return positions
+}
+
+// NLargest returns the n rows with the largest values in the given columns, compared in order,
+// sorted from largest. Rows with a null in any of the columns are skipped and ties keep their
+// original order. The index labels of the selected rows are kept.
+func (df DataFrame) NLargest(n int, columns ...string) DataFrame {
+	return df.takeRows(df.topRows(n, true, columns))
+}
+
+// NSmallest returns the n rows with the smallest values in the given columns, like NLargest.
+func (df DataFrame) NSmallest(n int, columns ...string) DataFrame {
+	return df.takeRows(df.topRows(n, false, columns))
+}
+
+// topRows selects the positions of the n best rows by columns, skipping rows with a null key.
+func (df DataFrame) topRows(n int, largest bool, columns []string) []int {
+	if len(columns) == 0 {
+		panic("no columns specified")
+	}
+	cols := df.keyColumns(columns)
+	keep := func(i int) bool { return !anyNullKey(cols, i) }
+	return topn.Positions(n, df.nrows, keep, func(i, j int) int {
+		if largest {
+			return compareRows(cols, i, j)
+		}
+		return compareRows(cols, j, i)
+	})
+}
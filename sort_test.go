@@ -0,0 +1,51 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestCompareValues(t *testing.T) {
+	assert.Equal(t, compareValues(1, 2), -1)
+	assert.Equal(t, compareValues(2.5, 2), 1)
+	assert.Equal(t, compareValues(2, 2.0), 0)
+	assert.Equal(t, compareValues(false, true), -1)
+	assert.Equal(t, compareValues("b", "a"), 1)
+	assert.Equal(t, compareValues(nil, 1), 1)
+	assert.Equal(t, compareValues(1, nil), -1)
+	assert.Equal(t, compareValues(nil, nil), 0)
+}
+
+func TestSortedPositions_Stable(t *testing.T) {
+	k := series.New([]int{2, 1, 2, 1}, series.Int, "k")
+	v := series.New([]string{"x", "y", "a", "y"}, series.String, "v")
+
+	pos := sortedPositions([]series.Series{k, v}, 4)
+	expected := []int{1, 3, 2, 0}
+	for i := range expected {
+		assert.Equal(t, pos[i], expected[i])
+	}
+}
+
+func TestDataFrame_NLargestNSmallest(t *testing.T) {
+	score := series.New([]any{90, 75, nil, 90, 80}, series.Int, "score")
+	df := New(
+		series.New([]string{"a", "b", "c", "d", "e"}, series.String, "name"),
+		score,
+		series.New([]int{1, 2, 3, 4, 0}, series.Int, "age"),
+	)
+
+	top := df.NLargest(3, "score")
+	assert.Equal(t, top.String(), "   name  score  age\n0     a     90    1\n3     d     90    4\n4     e     80    0")
+
+	byAge := df.NLargest(2, "score", "age")
+	assert.Equal(t, byAge.Column("name").Val(0), any("d"))
+	assert.Equal(t, byAge.Column("name").Val(1), any("a"))
+
+	bottom := df.NSmallest(2, "score")
+	assert.Equal(t, bottom.Column("name").Val(0), any("b"))
+	assert.Equal(t, bottom.Column("name").Val(1), any("e"))
+}
@@ -0,0 +1,203 @@
+package golumn
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/chriso345/golumn/series"
+)
+
+const (
+	// UnboundedPreceding extends a window frame to the first row of the partition.
+	UnboundedPreceding = math.MinInt
+	// UnboundedFollowing extends a window frame to the last row of the partition.
+	UnboundedFollowing = math.MaxInt
+)
+
+// Frame bounds a window frame by row offsets relative to the current row, so Rows(-3, 0) covers
+// the current row and the three before it. The zero Frame covers the whole partition.
+type Frame struct {
+	start, end int
+	bounded    bool
+}
+
+// Rows returns a Frame from start to end rows relative to the current row, inclusive.
+// Use UnboundedPreceding and UnboundedFollowing for open-ended frames.
+func Rows(start, end int) Frame {
+	return Frame{start: start, end: end, bounded: true}
+}
+
+// WindowSpec defines how DataFrame.Window partitions and orders rows.
+type WindowSpec struct {
+	PartitionBy []string // columns whose values split the rows into independent partitions
+	OrderBy     []string // columns ordering the rows of each partition, ascending with nulls last
+	Frame       Frame    // rows used by FirstValue, LastValue and Agg; defaults to the whole partition
+}
+
+// Window evaluates SQL-style window functions over the rows of a DataFrame. Every function
+// returns a series aligned with the original rows of the DataFrame.
+type Window struct {
+	parent DataFrame
+	spec   WindowSpec
+	parts  [][]int // row positions of each partition, in window order
+}
+
+// Window partitions and orders the rows of df as described by spec.
+func (df DataFrame) Window(spec WindowSpec) Window {
+	var parts [][]int
+	if len(spec.PartitionBy) > 0 {
+		parts = newKeyIndex(df.keyColumns(spec.PartitionBy), false).groups
+	} else {
+		all := make([]int, df.nrows)
+		for i := range all {
+			all[i] = i
+		}
+		parts = [][]int{all}
+	}
+
+	if len(spec.OrderBy) > 0 {
+		order := df.keyColumns(spec.OrderBy)
+		for _, rows := range parts {
+			slices.SortStableFunc(rows, func(i, j int) int {
+				return compareRows(order, i, j)
+			})
+		}
+	}
+
+	return Window{parent: df, spec: spec, parts: parts}
+}
+
+// RowNumber numbers the rows of each partition from 1 in window order.
+func (w Window) RowNumber() series.Series {
+	vals := make([]int, w.parent.nrows)
+	for _, rows := range w.parts {
+		for k, pos := range rows {
+			vals[pos] = k + 1
+		}
+	}
+	return series.New(vals, series.Int, "row_number")
+}
+
+// Rank ranks the rows of each partition by the OrderBy columns. Ties share the same rank and
+// leave a gap after them, so ranks may run 1, 2, 2, 4.
+func (w Window) Rank() series.Series {
+	return w.rank(false, "rank")
+}
+
+// DenseRank ranks the rows of each partition like Rank but without gaps after ties.
+func (w Window) DenseRank() series.Series {
+	return w.rank(true, "dense_rank")
+}
+
+func (w Window) rank(dense bool, name string) series.Series {
+	order := w.parent.keyColumns(w.spec.OrderBy)
+	vals := make([]int, w.parent.nrows)
+	for _, rows := range w.parts {
+		rank := 0
+		for k, pos := range rows {
+			if k == 0 || compareRows(order, rows[k-1], pos) != 0 {
+				if dense {
+					rank++
+				} else {
+					rank = k + 1
+				}
+			}
+			vals[pos] = rank
+		}
+	}
+	return series.New(vals, series.Int, name)
+}
+
+// Lag returns the value of col n rows before each row in its partition, or null if there is none.
+func (w Window) Lag(col string, n int) series.Series {
+	return w.shift(col, -n)
+}
+
+// Lead returns the value of col n rows after each row in its partition, or null if there is none.
+func (w Window) Lead(col string, n int) series.Series {
+	return w.shift(col, n)
+}
+
+func (w Window) shift(col string, offset int) series.Series {
+	src := *w.parent.Column(col)
+	positions := make([]int, w.parent.nrows)
+	for _, rows := range w.parts {
+		for k, pos := range rows {
+			positions[pos] = -1
+			if j := k + offset; j >= 0 && j < len(rows) {
+				positions[pos] = rows[j]
+			}
+		}
+	}
+	return src.Take(positions)
+}
+
+// CumSum returns the running sum of col over each partition in window order, skipping nulls.
+func (w Window) CumSum(col string) series.Series {
+	src := *w.parent.Column(col)
+	if !src.IsNumeric() {
+		panic(fmt.Errorf("cumulative sum is only supported for numeric types, got %v", src.Type()))
+	}
+
+	vals := make([]any, w.parent.nrows)
+	for _, rows := range w.parts {
+		isum, fsum := 0, 0.0
+		for _, pos := range rows {
+			if src.IsValid(pos) {
+				if src.Type() == series.Float {
+					fsum += src.Val(pos).(float64)
+				} else {
+					n, _ := toInt(src.Val(pos))
+					isum += n
+				}
+			}
+			if src.Type() == series.Float {
+				vals[pos] = fsum
+			} else {
+				vals[pos] = isum
+			}
+		}
+	}
+	return series.New(vals, AggSum.resultType(src.Type()), col)
+}
+
+// FirstValue returns the first non-null value of col within each row's frame.
+func (w Window) FirstValue(col string) series.Series {
+	return w.Agg(col, AggFirst)
+}
+
+// LastValue returns the last non-null value of col within each row's frame.
+func (w Window) LastValue(col string) series.Series {
+	return w.Agg(col, AggLast)
+}
+
+// Agg applies agg to the values of col within each row's frame.
+func (w Window) Agg(col string, agg AggFunc) series.Series {
+	src := *w.parent.Column(col)
+	vals := make([]any, w.parent.nrows)
+	for _, rows := range w.parts {
+		for k, pos := range rows {
+			lo, hi := w.frameBounds(k, len(rows))
+			vals[pos] = agg.apply(src, rows[lo:max(lo, hi)])
+		}
+	}
+	return series.New(vals, agg.resultType(src.Type()), col)
+}
+
+// frameBounds returns the half-open range of partition offsets covered by the frame of row k.
+func (w Window) frameBounds(k, n int) (int, int) {
+	f := w.spec.Frame
+	if !f.bounded {
+		return 0, n
+	}
+
+	lo, hi := 0, n
+	if f.start != UnboundedPreceding {
+		lo = max(0, min(n, k+f.start))
+	}
+	if f.end != UnboundedFollowing {
+		hi = max(0, min(n, k+f.end+1))
+	}
+	return lo, hi
+}
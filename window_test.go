@@ -0,0 +1,115 @@
+package golumn
+
+import (
+	"testing"
+
+	"github.com/chriso345/gore/assert"
+
+	"github.com/chriso345/golumn/series"
+)
+
+func TestWindow_Ranking(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a", "a"}, series.String, "g"),
+		series.New([]int{3, 1, 1, 2, 2, 2}, series.Int, "t"),
+		series.New([]int{30, 100, 10, 200, 20, 25}, series.Int, "v"),
+	)
+
+	w := df.Window(WindowSpec{PartitionBy: []string{"g"}, OrderBy: []string{"t"}})
+
+	rowNumber := w.RowNumber()
+	expected := []any{4, 1, 1, 2, 2, 3}
+	for i := range expected {
+		assert.Equal(t, rowNumber.Val(i), expected[i])
+	}
+
+	rank := w.Rank()
+	expected = []any{4, 1, 1, 2, 2, 2}
+	for i := range expected {
+		assert.Equal(t, rank.Val(i), expected[i])
+	}
+
+	dense := w.DenseRank()
+	expected = []any{3, 1, 1, 2, 2, 2}
+	for i := range expected {
+		assert.Equal(t, dense.Val(i), expected[i])
+	}
+}
+
+func TestWindow_LagLead(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a", "a"}, series.String, "g"),
+		series.New([]int{3, 1, 1, 2, 2, 2}, series.Int, "t"),
+		series.New([]int{30, 100, 10, 200, 20, 25}, series.Int, "v"),
+	)
+
+	w := df.Window(WindowSpec{PartitionBy: []string{"g"}, OrderBy: []string{"t"}})
+
+	lag := w.Lag("v", 1)
+	expected := []any{25, nil, nil, 100, 10, 20}
+	for i := range expected {
+		assert.Equal(t, lag.Val(i), expected[i])
+	}
+
+	lead := w.Lead("v", 2)
+	expected = []any{nil, nil, 25, nil, 30, nil}
+	for i := range expected {
+		assert.Equal(t, lead.Val(i), expected[i])
+	}
+}
+
+func TestWindow_Frames(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a", "a"}, series.String, "g"),
+		series.New([]int{3, 1, 1, 2, 2, 2}, series.Int, "t"),
+		series.New([]int{30, 100, 10, 200, 20, 25}, series.Int, "v"),
+	)
+	df.Column("v").Elem(4).Set(nil)
+
+	w := df.Window(WindowSpec{PartitionBy: []string{"g"}, OrderBy: []string{"t"}})
+	cum := w.CumSum("v")
+	expected := []any{65, 100, 10, 300, 10, 35}
+	for i := range expected {
+		assert.Equal(t, cum.Val(i), expected[i])
+	}
+
+	first := w.FirstValue("v")
+	last := w.LastValue("v")
+	for _, i := range []int{0, 2, 4, 5} {
+		assert.Equal(t, first.Val(i), any(10))
+		assert.Equal(t, last.Val(i), any(30))
+	}
+
+	moving := df.Window(WindowSpec{
+		PartitionBy: []string{"g"},
+		OrderBy:     []string{"t"},
+		Frame:       Rows(-1, 0),
+	})
+	sum := moving.Agg("v", AggSum)
+	expected = []any{55, 100, 10, 300, 10, 25}
+	for i := range expected {
+		assert.Equal(t, sum.Val(i), expected[i])
+	}
+
+	// a frame entirely outside the partition has no values
+	ahead := df.Window(WindowSpec{OrderBy: []string{"t"}, Frame: Rows(10, UnboundedFollowing)})
+	assert.Equal(t, ahead.Agg("v", AggCount).Val(0), any(0))
+	assert.Equal(t, ahead.Agg("v", AggMax).IsNull(0), true)
+
+	running := df.Window(WindowSpec{OrderBy: []string{"t"}, Frame: Rows(UnboundedPreceding, 0)})
+	count := running.Agg("v", AggCount)
+	expected = []any{5, 1, 2, 3, 3, 4}
+	for i := range expected {
+		assert.Equal(t, count.Val(i), expected[i])
+	}
+}
+
+func TestDataFrame_SortMultipleColumns(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b", "a", "a"}, series.String, "g"),
+		series.New([]int{3, 1, 1, 2, 2, 2}, series.Int, "t"),
+		series.New([]int{30, 100, 10, 200, 20, 25}, series.Int, "v"),
+	)
+	df.Sort("g", "t")
+	assert.Equal(t, df.String(), "   g  t    v\n2  a  1   10\n4  a  2   20\n5  a  2   25\n0  a  3   30\n1  b  1  100\n3  b  2  200")
+}